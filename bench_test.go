@@ -5,67 +5,12 @@
 package main
 
 import (
+	"math/rand"
 	"net/http"
-	"os"
-	"regexp"
-	"runtime"
 	"strings"
 	"testing"
 )
 
-var benchRe *regexp.Regexp
-
-func isTested(name string) bool {
-	if benchRe == nil {
-		// Get -test.bench flag value (not accessible via flag package)
-		bench := ""
-		for _, arg := range os.Args {
-			if strings.HasPrefix(arg, "-test.bench=") {
-				// ignore the benchmark name after an underscore
-				bench = strings.SplitN(arg[12:], "_", 2)[0]
-				break
-			}
-		}
-
-		// Compile RegExp to match Benchmark names
-		var err error
-		benchRe, err = regexp.Compile(bench)
-		if err != nil {
-			panic(err.Error())
-		}
-	}
-	return benchRe.MatchString(name)
-}
-
-func calcMem(name string, load func()) {
-	if !isTested(name) {
-		return
-	}
-
-	m := new(runtime.MemStats)
-
-	// before
-	// force GC multiple times, since Go is using a generational GC
-	// TODO: find a better approach
-	runtime.GC()
-	runtime.GC()
-	runtime.GC()
-	runtime.GC()
-	runtime.ReadMemStats(m)
-	before := m.HeapAlloc
-
-	load()
-
-	// after
-	runtime.GC()
-	runtime.GC()
-	runtime.GC()
-	runtime.GC()
-	runtime.ReadMemStats(m)
-	after := m.HeapAlloc
-	println("   "+name+":", after-before, "Bytes")
-}
-
 func benchRequest(b *testing.B, router http.Handler, r *http.Request) {
 	w := new(mockResponseWriter)
 	u := r.URL
@@ -81,859 +26,382 @@ func benchRequest(b *testing.B, router http.Handler, r *http.Request) {
 	}
 }
 
-func benchRoutes(b *testing.B, router http.Handler, routes []route) {
+// sentRequest is a request made by the benchmarks: the request target as it
+// is sent and the parts of the URL it is parsed into, like in url.URL.
+type sentRequest struct {
+	method     string
+	requestURI string
+	path       string
+	rawPath    string
+	rawQuery   string
+}
+
+// sentRequests returns the requests for the paths of the routes, which are
+// sent as they are.
+func sentRequests(routes []route) []sentRequest {
+	requests := make([]sentRequest, len(routes))
+	for i, route := range routes {
+		requests[i] = sentRequest{method: route.method, requestURI: route.path, path: route.path}
+	}
+	return requests
+}
+
+// benchSent makes perOp requests per op, cycling through the requests. All
+// benchmarks with more than one request are built on it.
+func benchSent(b *testing.B, router http.Handler, requests []sentRequest, perOp int) {
 	w := new(mockResponseWriter)
 	r, _ := http.NewRequest("GET", "/", nil)
 	u := r.URL
-	rq := u.RawQuery
 
 	b.ReportAllocs()
 	b.ResetTimer()
 
-	for i := 0; i < b.N; i++ {
-		for _, route := range routes {
-			r.Method = route.method
-			r.RequestURI = route.path
-			u.Path = route.path
-			u.RawQuery = rq
+	for i, j := 0, 0; i < b.N; i++ {
+		for k := 0; k < perOp; k++ {
+			req := &requests[j]
+			r.Method = req.method
+			r.RequestURI = req.requestURI
+			u.Path = req.path
+			u.RawPath = req.rawPath
+			u.RawQuery = req.rawQuery
 			router.ServeHTTP(w, r)
+
+			if j++; j == len(requests) {
+				j = 0
+			}
 		}
 	}
 }
 
-// Micro Benchmarks
-
-// Route with Param (no write)
-func BenchmarkAce_Param(b *testing.B) {
-	router := loadAceSingle("GET", "/user/:name", aceHandle)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkAero_Param(b *testing.B) {
-	router := loadAeroSingle("GET", "/user/:name", aeroHandler)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkBear_Param(b *testing.B) {
-	router := loadBearSingle("GET", "/user/{name}", bearHandler)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkBeego_Param(b *testing.B) {
-	router := loadBeegoSingle("GET", "/user/:name", beegoHandler)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkBone_Param(b *testing.B) {
-	router := loadBoneSingle("GET", "/user/:name", http.HandlerFunc(httpHandlerFunc))
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkChi_Param(b *testing.B) {
-	router := loadChiSingle("GET", "/user/{name}", httpHandlerFunc)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkCloudyKitRouter_Param(b *testing.B) {
-	router := loadCloudyKitRouterSingle("GET", "/user/:name", cloudyKitRouterHandler)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkDenco_Param(b *testing.B) {
-	router := loadDencoSingle("GET", "/user/:name", dencoHandler)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkEcho_Param(b *testing.B) {
-	router := loadEchoSingle("GET", "/user/:name", echoHandler)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGin_Param(b *testing.B) {
-	router := loadGinSingle("GET", "/user/:name", ginHandle)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGocraftWeb_Param(b *testing.B) {
-	router := loadGocraftWebSingle("GET", "/user/:name", gocraftWebHandler)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGoji_Param(b *testing.B) {
-	router := loadGojiSingle("GET", "/user/:name", httpHandlerFunc)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGojiv2_Param(b *testing.B) {
-	router := loadGojiv2Single("GET", "/user/:name", gojiv2Handler)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGoJsonRest_Param(b *testing.B) {
-	router := loadGoJsonRestSingle("GET", "/user/:name", goJsonRestHandler)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGoRestful_Param(b *testing.B) {
-	router := loadGoRestfulSingle("GET", "/user/{name}", goRestfulHandler)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGorillaMux_Param(b *testing.B) {
-	router := loadGorillaMuxSingle("GET", "/user/{name}", httpHandlerFunc)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGowwwRouter_Param(b *testing.B) {
-	router := loadGowwwRouterSingle("GET", "/user/:name", http.HandlerFunc(httpHandlerFunc))
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkHttpRouter_Param(b *testing.B) {
-	router := loadHttpRouterSingle("GET", "/user/:name", httpRouterHandle)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkHttpTreeMux_Param(b *testing.B) {
-	router := loadHttpTreeMuxSingle("GET", "/user/:name", httpTreeMuxHandler)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkKocha_Param(b *testing.B) {
-	handler := new(kochaHandler)
-	router := loadKochaSingle(
-		"GET", "/user/:name",
-		handler, http.HandlerFunc(handler.Get),
-	)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkLARS_Param(b *testing.B) {
-	router := loadLARSSingle("GET", "/user/:name", larsHandler)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkMacaron_Param(b *testing.B) {
-	router := loadMacaronSingle("GET", "/user/:name", macaronHandler)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkMartini_Param(b *testing.B) {
-	router := loadMartiniSingle("GET", "/user/:name", martiniHandler)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkPat_Param(b *testing.B) {
-	router := loadPatSingle("GET", "/user/:name", http.HandlerFunc(httpHandlerFunc))
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkPossum_Param(b *testing.B) {
-	router := loadPossumSingle("GET", "/user/:name", possumHandler)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
+// benchRoutes requests every route once per op, at the corresponding path of
+// paths.
+func benchRoutes(b *testing.B, router http.Handler, routes []route, paths []string) {
+	requests := make([]route, len(routes))
+	for i, r := range routes {
+		requests[i] = route{r.method, paths[i]}
+	}
+	benchSent(b, router, sentRequests(requests), len(requests))
 }
-func BenchmarkR2router_Param(b *testing.B) {
-	router := loadR2routerSingle("GET", "/user/:name", r2routerHandler)
 
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
+// benchRequests makes one request per op, cycling through the requests.
+func benchRequests(b *testing.B, router http.Handler, requests []route) {
+	benchSent(b, router, sentRequests(requests), 1)
 }
 
-// func BenchmarkRevel_Param(b *testing.B) {
-// 	router := loadRevelSingle("GET", "/user/:name", "RevelController.Handle")
-
-// 	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-// 	benchRequest(b, router, r)
-// }
-func BenchmarkRivet_Param(b *testing.B) {
-	router := loadRivetSingle("GET", "/user/:name", rivetHandler)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkTango_Param(b *testing.B) {
-	router := loadTangoSingle("GET", "/user/:name", tangoHandler)
+// handler returns the router loaded with all routes of the API. Routers are
+// only loaded when they are needed for the first time, which is also when
+// their memory consumption is measured.
+func (a *api) handler(router *routerAdapter) http.Handler {
+	if h, ok := a.handlers[router.name]; ok {
+		return h
+	}
 
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
+	routes := router.supportedRoutes(a.routes)
+	h := calcMem(router.name, a.name, len(routes), func() http.Handler {
+		return router.build(routes, noopHandler)
+	})
+	if a.handlers == nil {
+		a.handlers = make(map[string]http.Handler)
+	}
+	a.handlers[router.name] = h
+	return h
+}
+
+// supported returns the routes of the API with a method the router handles,
+// their paths and the indices of all routes of the API among them, which
+// are -1 for the skipped ones.
+func (a *api) supported(router *routerAdapter) (routes []route, paths []string, index []int) {
+	index = make([]int, len(a.routes))
+	for i, route := range a.routes {
+		index[i] = -1
+		if router.handles(route.method) {
+			index[i] = len(routes)
+			routes = append(routes, route)
+			paths = append(paths, a.paths[i])
+		}
+	}
+	return routes, paths, index
 }
-func BenchmarkTigerTonic_Param(b *testing.B) {
-	router := loadTigerTonicSingle("GET", "/user/{name}", httpHandlerFunc)
 
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
+// sampleRequest returns the request path of the first GET route with exactly
+// n parameters, or an empty string if there is none.
+func (a *api) sampleRequest(n int) string {
+	for i, route := range a.routes {
+		if route.method != "GET" || strings.Contains(route.path, "/*") ||
+			strings.Count(route.path, ":") != n {
+			continue
+		}
+		return a.paths[i]
+	}
+	return ""
 }
-func BenchmarkTraffic_Param(b *testing.B) {
-	router := loadTrafficSingle("GET", "/user/:name", trafficHandler)
 
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
+// generateRequests generates the request paths of the API and derives the
+// requests of the single request benchmarks which are not set explicitly.
+// It is called for all APIs once the flags are parsed.
+func (a *api) generateRequests() {
+	a.paths = concretePaths(a.routes)
+	if a.static == "" {
+		a.static = a.sampleRequest(0)
+	}
+	if a.param == "" {
+		a.param = a.sampleRequest(1)
+	}
+	if a.twoParams == "" {
+		a.twoParams = a.sampleRequest(2)
+	}
+	a.generateMisses()
+	a.generateWrongMethods()
+	a.generateHeadOptions()
+	a.generateVariants()
+	a.generateCatchAlls()
+	a.generateConstrained()
+	a.generateEncoded()
+}
+
+// classRequests is the number of requests generated per API and class of
+// requests, e.g. per miss class.
+const classRequests = 16
+
+// sampleRoutes calls generate with the indices of the routes of the API in
+// random order, until it reported classRequests times that it generated a
+// request.
+func (a *api) sampleRoutes(rnd *rand.Rand, generate func(i int) bool) {
+	n := 0
+	for _, i := range rnd.Perm(len(a.routes)) {
+		if n == classRequests {
+			return
+		}
+		if generate(i) {
+			n++
+		}
+	}
 }
-func BenchmarkVulcan_Param(b *testing.B) {
-	router := loadVulcanSingle("GET", "/user/:name", vulcanHandler)
 
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
+// spreadRoutes calls generate classRequests times, cycling through the
+// routes, unless there are none.
+func spreadRoutes(routes []route, generate func(r route)) {
+	for i := 0; len(routes) > 0 && i < classRequests; i++ {
+		generate(routes[i%len(routes)])
+	}
 }
 
-// func BenchmarkZeus_Param(b *testing.B) {
-// 	router := loadZeusSingle("GET", "/user/:name", http.HandlerFunc(httpHandlerFunc))
-
-// 	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-// 	benchRequest(b, router, r)
-// }
-
-// Route with 5 Params (no write)
+// Micro Benchmarks
+// Only a single route is loaded into the router, which is then requested.
 const fiveColon = "/:a/:b/:c/:d/:e"
-const fiveBrace = "/{a}/{b}/{c}/{d}/{e}"
 const fiveRoute = "/test/test/test/test/test"
 
-func BenchmarkAce_Param5(b *testing.B) {
-	router := loadAceSingle("GET", fiveColon, aceHandle)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkAero_Param5(b *testing.B) {
-	router := loadAeroSingle("GET", fiveColon, aeroHandler)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkBear_Param5(b *testing.B) {
-	router := loadBearSingle("GET", fiveBrace, bearHandler)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkBeego_Param5(b *testing.B) {
-	router := loadBeegoSingle("GET", fiveColon, beegoHandler)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkBone_Param5(b *testing.B) {
-	router := loadBoneSingle("GET", fiveColon, http.HandlerFunc(httpHandlerFunc))
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkChi_Param5(b *testing.B) {
-	router := loadChiSingle("GET", fiveBrace, httpHandlerFunc)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkCloudyKitRouter_Param5(b *testing.B) {
-	router := loadCloudyKitRouterSingle("GET", fiveColon, cloudyKitRouterHandler)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkDenco_Param5(b *testing.B) {
-	router := loadDencoSingle("GET", fiveColon, dencoHandler)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkEcho_Param5(b *testing.B) {
-	router := loadEchoSingle("GET", fiveColon, echoHandler)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGin_Param5(b *testing.B) {
-	router := loadGinSingle("GET", fiveColon, ginHandle)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGocraftWeb_Param5(b *testing.B) {
-	router := loadGocraftWebSingle("GET", fiveColon, gocraftWebHandler)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGoji_Param5(b *testing.B) {
-	router := loadGojiSingle("GET", fiveColon, httpHandlerFunc)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGojiv2_Param5(b *testing.B) {
-	router := loadGojiv2Single("GET", fiveColon, gojiv2Handler)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGoJsonRest_Param5(b *testing.B) {
-	handler := loadGoJsonRestSingle("GET", fiveColon, goJsonRestHandler)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, handler, r)
-}
-func BenchmarkGoRestful_Param5(b *testing.B) {
-	router := loadGoRestfulSingle("GET", fiveBrace, goRestfulHandler)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGorillaMux_Param5(b *testing.B) {
-	router := loadGorillaMuxSingle("GET", fiveBrace, httpHandlerFunc)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGowwwRouter_Param5(b *testing.B) {
-	router := loadGowwwRouterSingle("GET", fiveColon, http.HandlerFunc(httpHandlerFunc))
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkHttpRouter_Param5(b *testing.B) {
-	router := loadHttpRouterSingle("GET", fiveColon, httpRouterHandle)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkHttpTreeMux_Param5(b *testing.B) {
-	router := loadHttpTreeMuxSingle("GET", fiveColon, httpTreeMuxHandler)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkKocha_Param5(b *testing.B) {
-	handler := new(kochaHandler)
-	router := loadKochaSingle(
-		"GET", fiveColon,
-		handler, http.HandlerFunc(handler.Get),
-	)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkLARS_Param5(b *testing.B) {
-	router := loadLARSSingle("GET", fiveColon, larsHandler)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkMacaron_Param5(b *testing.B) {
-	router := loadMacaronSingle("GET", fiveColon, macaronHandler)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkMartini_Param5(b *testing.B) {
-	router := loadMartiniSingle("GET", fiveColon, martiniHandler)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkPat_Param5(b *testing.B) {
-	router := loadPatSingle("GET", fiveColon, http.HandlerFunc(httpHandlerFunc))
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkPossum_Param5(b *testing.B) {
-	router := loadPossumSingle("GET", fiveColon, possumHandler)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkR2router_Param5(b *testing.B) {
-	router := loadR2routerSingle("GET", fiveColon, r2routerHandler)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-
-// func BenchmarkRevel_Param5(b *testing.B) {
-// 	router := loadRevelSingle("GET", fiveColon, "RevelController.Handle")
-
-// 	r, _ := http.NewRequest("GET", fiveRoute, nil)
-// 	benchRequest(b, router, r)
-// }
-func BenchmarkRivet_Param5(b *testing.B) {
-	router := loadRivetSingle("GET", fiveColon, rivetHandler)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkTango_Param5(b *testing.B) {
-	router := loadTangoSingle("GET", fiveColon, tangoHandler)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkTigerTonic_Param5(b *testing.B) {
-	router := loadTigerTonicSingle("GET", fiveBrace, httpHandlerFunc)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkTraffic_Param5(b *testing.B) {
-	router := loadTrafficSingle("GET", fiveColon, trafficHandler)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkVulcan_Param5(b *testing.B) {
-	router := loadVulcanSingle("GET", fiveColon, vulcanHandler)
-
-	r, _ := http.NewRequest("GET", fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-
-// func BenchmarkZeus_Param5(b *testing.B) {
-// 	router := loadZeusSingle("GET", fiveColon, http.HandlerFunc(httpHandlerFunc))
-
-// 	r, _ := http.NewRequest("GET", fiveRoute, nil)
-// 	benchRequest(b, router, r)
-// }
-
-// Route with 20 Params (no write)
 const twentyColon = "/:a/:b/:c/:d/:e/:f/:g/:h/:i/:j/:k/:l/:m/:n/:o/:p/:q/:r/:s/:t"
-const twentyBrace = "/{a}/{b}/{c}/{d}/{e}/{f}/{g}/{h}/{i}/{j}/{k}/{l}/{m}/{n}/{o}/{p}/{q}/{r}/{s}/{t}"
 const twentyRoute = "/a/b/c/d/e/f/g/h/i/j/k/l/m/n/o/p/q/r/s/t"
 
-func BenchmarkAce_Param20(b *testing.B) {
-	router := loadAceSingle("GET", twentyColon, aceHandle)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkAero_Param20(b *testing.B) {
-	router := loadAeroSingle("GET", twentyBrace, aeroHandler)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkBear_Param20(b *testing.B) {
-	router := loadBearSingle("GET", twentyBrace, bearHandler)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkBeego_Param20(b *testing.B) {
-	router := loadBeegoSingle("GET", twentyColon, beegoHandler)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkBone_Param20(b *testing.B) {
-	router := loadBoneSingle("GET", twentyColon, http.HandlerFunc(httpHandlerFunc))
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkChi_Param20(b *testing.B) {
-	router := loadChiSingle("GET", twentyBrace, httpHandlerFunc)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkCloudyKitRouter_Param20(b *testing.B) {
-	router := loadCloudyKitRouterSingle("GET", twentyColon, cloudyKitRouterHandler)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkDenco_Param20(b *testing.B) {
-	router := loadDencoSingle("GET", twentyColon, dencoHandler)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkEcho_Param20(b *testing.B) {
-	router := loadEchoSingle("GET", twentyColon, echoHandler)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGin_Param20(b *testing.B) {
-	router := loadGinSingle("GET", twentyColon, ginHandle)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGocraftWeb_Param20(b *testing.B) {
-	router := loadGocraftWebSingle("GET", twentyColon, gocraftWebHandler)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGoji_Param20(b *testing.B) {
-	router := loadGojiSingle("GET", twentyColon, httpHandlerFunc)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGojiv2_Param20(b *testing.B) {
-	router := loadGojiv2Single("GET", twentyColon, gojiv2Handler)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGoJsonRest_Param20(b *testing.B) {
-	handler := loadGoJsonRestSingle("GET", twentyColon, goJsonRestHandler)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, handler, r)
-}
-func BenchmarkGoRestful_Param20(b *testing.B) {
-	handler := loadGoRestfulSingle("GET", twentyBrace, goRestfulHandler)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, handler, r)
-}
-func BenchmarkGorillaMux_Param20(b *testing.B) {
-	router := loadGorillaMuxSingle("GET", twentyBrace, httpHandlerFunc)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGowwwRouter_Param20(b *testing.B) {
-	router := loadGowwwRouterSingle("GET", twentyColon, http.HandlerFunc(httpHandlerFunc))
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkHttpRouter_Param20(b *testing.B) {
-	router := loadHttpRouterSingle("GET", twentyColon, httpRouterHandle)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkHttpTreeMux_Param20(b *testing.B) {
-	router := loadHttpTreeMuxSingle("GET", twentyColon, httpTreeMuxHandler)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkKocha_Param20(b *testing.B) {
-	handler := new(kochaHandler)
-	router := loadKochaSingle(
-		"GET", twentyColon,
-		handler, http.HandlerFunc(handler.Get),
-	)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkLARS_Param20(b *testing.B) {
-	router := loadLARSSingle("GET", twentyColon, larsHandler)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkMacaron_Param20(b *testing.B) {
-	router := loadMacaronSingle("GET", twentyColon, macaronHandler)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkMartini_Param20(b *testing.B) {
-	router := loadMartiniSingle("GET", twentyColon, martiniHandler)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkPat_Param20(b *testing.B) {
-	router := loadPatSingle("GET", twentyColon, http.HandlerFunc(httpHandlerFunc))
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkPossum_Param20(b *testing.B) {
-	router := loadPossumSingle("GET", twentyColon, possumHandler)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkR2router_Param20(b *testing.B) {
-	router := loadR2routerSingle("GET", twentyColon, r2routerHandler)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-
-// func BenchmarkRevel_Param20(b *testing.B) {
-// 	router := loadRevelSingle("GET", twentyColon, "RevelController.Handle")
-
-// 	r, _ := http.NewRequest("GET", twentyRoute, nil)
-// 	benchRequest(b, router, r)
-// }
-func BenchmarkRivet_Param20(b *testing.B) {
-	router := loadRivetSingle("GET", twentyColon, rivetHandler)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkTango_Param20(b *testing.B) {
-	router := loadTangoSingle("GET", twentyColon, tangoHandler)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkTigerTonic_Param20(b *testing.B) {
-	router := loadTigerTonicSingle("GET", twentyBrace, httpHandlerFunc)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkTraffic_Param20(b *testing.B) {
-	router := loadTrafficSingle("GET", twentyColon, trafficHandler)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkVulcan_Param20(b *testing.B) {
-	router := loadVulcanSingle("GET", twentyColon, vulcanHandler)
-
-	r, _ := http.NewRequest("GET", twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-
-// func BenchmarkZeus_Param20(b *testing.B) {
-// 	router := loadZeusSingle("GET", twentyColon, http.HandlerFunc(httpHandlerFunc))
-
-// 	r, _ := http.NewRequest("GET", twentyRoute, nil)
-// 	benchRequest(b, router, r)
-// }
-
-// Route with Param and write
-func BenchmarkAce_ParamWrite(b *testing.B) {
-	router := loadAceSingle("GET", "/user/:name", aceHandleWrite)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkAero_ParamWrite(b *testing.B) {
-	router := loadAeroSingle("GET", "/user/:name", aeroHandlerTest)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkBear_ParamWrite(b *testing.B) {
-	router := loadBearSingle("GET", "/user/{name}", bearHandlerWrite)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkBeego_ParamWrite(b *testing.B) {
-	router := loadBeegoSingle("GET", "/user/:name", beegoHandlerWrite)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkBone_ParamWrite(b *testing.B) {
-	router := loadBoneSingle("GET", "/user/:name", http.HandlerFunc(boneHandlerWrite))
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkChi_ParamWrite(b *testing.B) {
-	router := loadChiSingle("GET", "/user/{name}", chiHandleWrite)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkCloudyKitRouter_ParamWrite(b *testing.B) {
-	router := loadCloudyKitRouterSingle("GET", "/user/:name", cloudyKitRouterHandler)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkDenco_ParamWrite(b *testing.B) {
-	router := loadDencoSingle("GET", "/user/:name", dencoHandlerWrite)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkEcho_ParamWrite(b *testing.B) {
-	router := loadEchoSingle("GET", "/user/:name", echoHandlerWrite)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGin_ParamWrite(b *testing.B) {
-	router := loadGinSingle("GET", "/user/:name", ginHandleWrite)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGocraftWeb_ParamWrite(b *testing.B) {
-	router := loadGocraftWebSingle("GET", "/user/:name", gocraftWebHandlerWrite)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGoji_ParamWrite(b *testing.B) {
-	router := loadGojiSingle("GET", "/user/:name", gojiFuncWrite)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGojiv2_ParamWrite(b *testing.B) {
-	router := loadGojiv2Single("GET", "/user/:name", gojiv2HandlerWrite)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGoJsonRest_ParamWrite(b *testing.B) {
-	handler := loadGoJsonRestSingle("GET", "/user/:name", goJsonRestHandlerWrite)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, handler, r)
-}
-func BenchmarkGoRestful_ParamWrite(b *testing.B) {
-	handler := loadGoRestfulSingle("GET", "/user/{name}", goRestfulHandlerWrite)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, handler, r)
-}
-func BenchmarkGorillaMux_ParamWrite(b *testing.B) {
-	router := loadGorillaMuxSingle("GET", "/user/{name}", gorillaHandlerWrite)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGowwwRouter_ParamWrite(b *testing.B) {
-	router := loadGowwwRouterSingle("GET", "/user/:name", http.HandlerFunc(gowwwRouterHandleWrite))
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkHttpRouter_ParamWrite(b *testing.B) {
-	router := loadHttpRouterSingle("GET", "/user/:name", httpRouterHandleWrite)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkHttpTreeMux_ParamWrite(b *testing.B) {
-	router := loadHttpTreeMuxSingle("GET", "/user/:name", httpTreeMuxHandlerWrite)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkKocha_ParamWrite(b *testing.B) {
-	handler := new(kochaHandler)
-	router := loadKochaSingle(
-		"GET", "/user/:name",
-		handler, http.HandlerFunc(handler.kochaHandlerWrite),
-	)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkLARS_ParamWrite(b *testing.B) {
-	router := loadLARSSingle("GET", "/user/:name", larsHandlerWrite)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkMacaron_ParamWrite(b *testing.B) {
-	router := loadMacaronSingle("GET", "/user/:name", macaronHandlerWrite)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkMartini_ParamWrite(b *testing.B) {
-	router := loadMartiniSingle("GET", "/user/:name", martiniHandlerWrite)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkPat_ParamWrite(b *testing.B) {
-	router := loadPatSingle("GET", "/user/:name", http.HandlerFunc(patHandlerWrite))
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkPossum_ParamWrite(b *testing.B) {
-	router := loadPossumSingle("GET", "/user/:name", possumHandlerWrite)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkR2router_ParamWrite(b *testing.B) {
-	router := loadR2routerSingle("GET", "/user/:name", r2routerHandleWrite)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-
-// func BenchmarkRevel_ParamWrite(b *testing.B) {
-// 	router := loadRevelSingle("GET", "/user/:name", "RevelController.HandleWrite")
-
-// 	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-// 	benchRequest(b, router, r)
-// }
-func BenchmarkRivet_ParamWrite(b *testing.B) {
-	router := loadRivetSingle("GET", "/user/:name", rivetHandlerWrite)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkTango_ParamWrite(b *testing.B) {
-	router := loadTangoSingle("GET", "/user/:name", tangoHandlerWrite)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkTigerTonic_ParamWrite(b *testing.B) {
-	router := loadTigerTonicSingle(
-		"GET", "/user/{name}",
-		http.HandlerFunc(tigerTonicHandlerWrite),
-	)
-
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkTraffic_ParamWrite(b *testing.B) {
-	router := loadTrafficSingle("GET", "/user/:name", trafficHandlerWrite)
+var microBenchmarks = []struct {
+	name    string
+	path    string
+	request string
+	mode    handlerMode
+}{
+	// Route with Param (no write)
+	{"Param", "/user/:name", "/user/gordon", noopHandler},
+	// Route with 5 Params (no write)
+	{"Param5", fiveColon, fiveRoute, noopHandler},
+	// Route with 20 Params (no write)
+	{"Param20", twentyColon, twentyRoute, noopHandler},
+	// Route with Param and write
+	{"ParamWrite", "/user/:name", "/user/gordon", writeHandler},
+}
+
+// API Benchmarks
+// The router is loaded with all routes of an API. Besides the All scenario,
+// which requests every route once, a single request is made. Those
+// scenarios are skipped for APIs without a matching request.
+var apiBenchmarks = []struct {
+	name    string
+	request func(a *api) string
+}{
+	{"Static", func(a *api) string { return a.static }},
+	{"Param", func(a *api) string { return a.param }},
+	{"2Params", func(a *api) string { return a.twoParams }},
+}
+
+// BenchmarkRouters runs every scenario for every registered router, e.g.
+// BenchmarkRouters/HttpRouter/Param5 or BenchmarkRouters/HttpRouter/GitHub/All.
+func BenchmarkRouters(b *testing.B) {
+	dists, err := parseDistributions(*distFlag)
+	if err != nil {
+		b.Fatal(err)
+	}
 
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkVulcan_ParamWrite(b *testing.B) {
-	router := loadVulcanSingle("GET", "/user/:name", vulcanHandlerWrite)
+	for _, router := range routers {
+		router := router
+		b.Run(router.name, func(b *testing.B) {
+			for _, bm := range microBenchmarks {
+				bm := bm
+				b.Run(bm.name, func(b *testing.B) {
+					if !router.supports([]route{{"GET", bm.path}}) {
+						b.Skip("unsupported")
+					}
+					h := router.loadSingle("GET", bm.path, bm.mode)
+
+					r, _ := http.NewRequest("GET", bm.request, nil)
+					benchRequest(b, h, r)
+				})
+			}
 
-	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-	benchRequest(b, router, r)
+			for _, api := range apis {
+				api := api
+				b.Run(api.name, func(b *testing.B) {
+					if !api.supportedBy(router) {
+						b.Skip("unsupported")
+					}
+					h := api.handler(router)
+					routes, paths, index := api.supported(router)
+					if skipped := len(api.routes) - len(routes); skipped > 0 {
+						b.Logf("skipping %d routes with methods not supported by %s", skipped, router.name)
+					}
+
+					for _, bm := range apiBenchmarks {
+						path := bm.request(api)
+						if path == "" {
+							continue
+						}
+						b.Run(bm.name, func(b *testing.B) {
+							r, _ := http.NewRequest("GET", path, nil)
+							benchRequest(b, h, r)
+						})
+					}
+
+					// All routes
+					b.Run("All", func(b *testing.B) {
+						benchRoutes(b, h, routes, paths)
+					})
+
+					// Near misses, one request per op
+					for c, paths := range api.misses {
+						paths := paths
+						if len(paths) == 0 {
+							continue
+						}
+						b.Run("NotFound/"+missClasses[c].name, func(b *testing.B) {
+							if router.skipUnmatched {
+								b.Skip("not supported by router")
+							}
+							benchRequests(b, h, getRequests(paths))
+						})
+					}
+
+					// Known paths with a method not allowed, one request per op
+					if len(api.wrongMethods) > 0 {
+						b.Run("MethodNotAllowed", func(b *testing.B) {
+							if router.skipUnmatched {
+								b.Skip("not supported by router")
+							}
+							benchRequests(b, h, api.wrongMethods)
+						})
+					}
+
+					// Automatic HEAD and OPTIONS handling, one request per op
+					for _, bm := range []struct {
+						name     string
+						requests []route
+					}{
+						{"Head", api.heads},
+						{"Options", api.options},
+					} {
+						bm := bm
+						if len(bm.requests) == 0 {
+							continue
+						}
+						b.Run(bm.name, func(b *testing.B) {
+							benchRequests(b, h, bm.requests)
+						})
+					}
+
+					// Unclean path variants, one request per op
+					for c, variants := range api.variants {
+						requests := variantRequests(variants)
+						if len(requests) == 0 {
+							continue
+						}
+						b.Run("PathVariant/"+variantClasses[c].name, func(b *testing.B) {
+							if router.skipUnmatched {
+								b.Skip("not supported by router")
+							}
+							benchRequests(b, h, requests)
+						})
+					}
+
+					// Catch-all routes, one request per op
+					for c, requests := range api.catchAlls {
+						requests := requests
+						if len(requests) == 0 {
+							continue
+						}
+						b.Run("CatchAll/"+catchAllClasses[c].name, func(b *testing.B) {
+							benchRequests(b, h, requests)
+						})
+					}
+
+					// Constrained parameters, one request per op
+					for c, requests := range api.constrained {
+						c, requests := c, requests
+						if len(requests) == 0 {
+							continue
+						}
+						b.Run("Constraint/"+constraintClasses[c].name, func(b *testing.B) {
+							if constraintClasses[c].unmatched && router.skipUnmatched {
+								b.Skip("not supported by router")
+							}
+							benchRequests(b, h, requests)
+						})
+					}
+
+					// Percent-encoded paths and the plain paths of the same
+					// routes, one request per op
+					for c, requests := range api.encoded {
+						requests := requests
+						if len(requests) == 0 {
+							continue
+						}
+						b.Run("Encoding/"+encodingClasses[c].name, func(b *testing.B) {
+							if router.skipUnmatched {
+								b.Skip("not supported by router")
+							}
+							b.Run("Encoded", func(b *testing.B) {
+								benchEncoded(b, h, requests, false)
+							})
+							b.Run("Plain", func(b *testing.B) {
+								benchEncoded(b, h, requests, true)
+							})
+						})
+					}
+
+					// All routes, requested according to a distribution
+					for _, dist := range dists {
+						seq := supportedSequence(dist.sequence(api), index)
+						if len(seq) == 0 {
+							continue
+						}
+						b.Run("Dist/"+dist.name, func(b *testing.B) {
+							benchSequence(b, h, routes, paths, seq)
+						})
+					}
+
+					// Recorded traffic, one request per op
+					for _, rp := range api.replays {
+						rp := rp
+						b.Run("Replay/"+rp.name, func(b *testing.B) {
+							requests, dropped := rp.supported(router, routes)
+							if dropped > 0 {
+								b.Logf("dropping %d requests not supported by %s", dropped, router.name)
+							}
+							if len(requests) == 0 {
+								b.Skip("not supported by router")
+							}
+							benchSent(b, h, requests, 1)
+						})
+					}
+				})
+			}
+		})
+	}
 }
-
-// func BenchmarkZeus_ParamWrite(b *testing.B) {
-// 	router := loadZeusSingle("GET", "/user/:name", zeusHandlerWrite)
-
-// 	r, _ := http.NewRequest("GET", "/user/gordon", nil)
-// 	benchRequest(b, router, r)
-// }
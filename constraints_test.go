@@ -0,0 +1,202 @@
+package main
+
+import (
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// Routes with parameters constrained by regular expressions, written as
+// :name(re). The API is only loaded into routers able to express all
+// constraints. The names of the parameters select a kind of values which
+// satisfies the constraint.
+var constrainedAPI = []route{
+	{"GET", "/users"},
+	{"GET", "/users/:user([a-z][a-z0-9-]*)"},
+	{"GET", "/users/:user([a-z][a-z0-9-]*)/repos"},
+
+	{"GET", "/repos"},
+	{"GET", "/repos/:id([0-9]+)"},
+	{"PUT", "/repos/:id([0-9]+)"},
+	{"DELETE", "/repos/:id([0-9]+)"},
+	{"GET", "/repos/:id([0-9]+)/commits"},
+	{"GET", "/repos/:id([0-9]+)/commits/:sha([0-9a-f]{40})"},
+	{"GET", "/repos/:id([0-9]+)/issues"},
+	{"POST", "/repos/:id([0-9]+)/issues"},
+	{"GET", "/repos/:id([0-9]+)/issues/:number([0-9]+)"},
+	{"PUT", "/repos/:id([0-9]+)/issues/:number([0-9]+)"},
+
+	{"GET", "/devices/:uuid([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})"},
+	{"DELETE", "/devices/:uuid([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})"},
+
+	{"GET", "/posts"},
+	{"GET", "/posts/:slug([a-z0-9-]+)"},
+}
+
+// constraintClasses derive a request from a path of a route with constrained
+// parameters, which may be empty if there is none.
+var constraintClasses = []struct {
+	name string
+
+	// unmatched marks classes of requests which no route matches.
+	unmatched bool
+
+	request func(path string, constraints []segmentConstraint, rnd *rand.Rand) string
+}{
+	// All constraints are satisfied.
+	{"Match", false, func(path string, _ []segmentConstraint, _ *rand.Rand) string {
+		return path
+	}},
+	// The constraint of the first constrained segment is violated.
+	{"Reject", true, func(path string, constraints []segmentConstraint, rnd *rand.Rand) string {
+		return violate(path, constraints[0].segment, constraints[0].re, rnd)
+	}},
+}
+
+var compiledConstraints = make(map[string]*regexp.Regexp)
+
+// constraintRe returns the compiled constraint, which has to match the whole
+// parameter value.
+func constraintRe(re string) *regexp.Regexp {
+	c, ok := compiledConstraints[re]
+	if !ok {
+		c = regexp.MustCompile("^(?:" + re + ")$")
+		compiledConstraints[re] = c
+	}
+	return c
+}
+
+// segmentConstraint is the constraint re of the parameter in the segment
+// with the index segment of a route.
+type segmentConstraint struct {
+	segment int
+	re      string
+}
+
+// constraints returns the constraints of the route, ordered by segment.
+func constraints(route string) []segmentConstraint {
+	var c []segmentConstraint
+	for i, s := range strings.Split(route, "/") {
+		if m := paramPattern.FindStringSubmatch(s); m != nil && m[2] != "" {
+			c = append(c, segmentConstraint{i, m[2]})
+		}
+	}
+	return c
+}
+
+// violatingKinds are tried in order for a value violating a constraint.
+var violatingKinds = []*paramKind{wordKind, idKind, fileKind, userKind}
+
+// violate replaces the value of the segment i of the path, which is
+// constrained by re, with a value violating the constraint. It returns an
+// empty string if no kind of values violates it.
+func violate(path string, i int, re string, rnd *rand.Rand) string {
+	segments := strings.Split(path, "/")
+	for _, k := range violatingKinds {
+		if v := k.generate(rnd); !constraintRe(re).MatchString(v) {
+			segments[i] = v
+			return strings.Join(segments, "/")
+		}
+	}
+	return ""
+}
+
+// generateConstrained generates up to classRequests requests for each
+// constraint class, spread over the routes with constrained parameters.
+func (a *api) generateConstrained() {
+	rnd := rand.New(rand.NewSource(*paramSeed))
+	a.constrained = make([][]route, len(constraintClasses))
+
+	var routes []route
+	for _, r := range a.routes {
+		if len(constraints(r.path)) > 0 {
+			routes = append(routes, r)
+		}
+	}
+	spreadRoutes(routes, func(r route) {
+		path := concretePath(r.path, rnd)
+		for c, class := range constraintClasses {
+			request := class.request(path, constraints(r.path), rnd)
+			if request == "" || class.unmatched && a.matches(request) {
+				continue
+			}
+			a.constrained[c] = append(a.constrained[c], route{r.method, request})
+		}
+	})
+}
+
+// TestConstraints checks that the routers supporting the constraints of an
+// API serve requests satisfying them and answer requests violating them
+// with 404. With -v, it prints which constraints the routers can express.
+func TestConstraints(t *testing.T) {
+	var res []string
+	for _, api := range apis {
+		for _, r := range api.routes {
+			for _, c := range constraints(r.path) {
+				if !contains(res, c.re) {
+					res = append(res, c.re)
+				}
+			}
+		}
+	}
+
+	var rows [][]string
+	for _, router := range routers {
+		row := []string{router.name}
+		for _, re := range res {
+			cell := "cannot express"
+			if router.constraint != nil && router.constraint(re) {
+				cell = "yes"
+			}
+			row = append(row, cell)
+		}
+		rows = append(rows, row)
+
+		if router.skipTest {
+			continue
+		}
+		for _, api := range apis {
+			if len(api.constrained) == 0 || len(api.constrained[0]) == 0 || !api.supportedBy(router) {
+				continue
+			}
+			h := router.build(api.routes, testHandler)
+
+			for c, requests := range api.constrained {
+				class := constraintClasses[c]
+				if class.unmatched && router.skipUnmatched {
+					continue
+				}
+				for _, request := range requests {
+					if !router.handles(request.method) {
+						continue
+					}
+					r, _ := http.NewRequest(request.method, "/", nil)
+					r.URL.Path = request.path
+					r.RequestURI = request.path
+					w := httptest.NewRecorder()
+					h.ServeHTTP(w, r)
+
+					switch {
+					case !class.unmatched && (w.Code != 200 || w.Body.String() != request.path):
+						t.Errorf("%s in API %s: %s request %s %s: got %d - %s",
+							router.name, api.name, class.name, request.method, request.path, w.Code, w.Body.String())
+					case class.unmatched && w.Code != http.StatusNotFound:
+						t.Errorf("%s in API %s: %s request %s %s: got %d, expected 404",
+							router.name, api.name, class.name, request.method, request.path, w.Code)
+					}
+				}
+			}
+		}
+	}
+
+	if testing.Verbose() {
+		header := append([]string{"Router"}, res...)
+		if err := writeMarkdownTable(os.Stdout, header, rows); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
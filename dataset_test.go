@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Route files describe an API in plain text, one route per line:
+//
+//	# comments start with #
+//	@name     Example
+//	@static   /users
+//	@param    /users/gordon
+//	@2params  /users/gordon/repos/httprouter
+//
+//	GET    /users
+//	GET    /users/:user               20
+//	DELETE /users/:user/repos/:repo
+//
+// The optional @name sets the name of the API, which defaults to the file
+// name without extension. @static, @param and @2params set the requests of
+// the single request benchmarks. A route may be followed by its weight in the
+// Weighted traffic distribution, which defaults to 1.
+//
+// All route files in routesDir and those given by the routes flag are added
+// as APIs.
+const routesDir = "testdata/routes"
+
+const routesExt = ".routes"
+
+var routeFiles fileList
+
+func init() {
+	flag.Var(&routeFiles, "routes", "add the routes in `file` as API, or all "+routesExt+" files if it is a directory; may be repeated")
+}
+
+// loadRouteFiles adds the APIs of all route files.
+func loadRouteFiles() error {
+	paths := append([]string{routesDir}, routeFiles...)
+	for i, path := range paths {
+		fi, err := os.Stat(path)
+		if i == 0 && os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		files := []string{path}
+		if fi.IsDir() {
+			if files, err = filepath.Glob(filepath.Join(path, "*"+routesExt)); err != nil {
+				return err
+			}
+		}
+		for _, file := range files {
+			if err = loadRouteFile(file); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func loadRouteFile(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	a, err := parseRoutes(f)
+	if err != nil {
+		return fmt.Errorf("%s:%v", file, err)
+	}
+	if a.name == "" {
+		a.name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+	if err = addAPI(a); err != nil {
+		return fmt.Errorf("%s: %v", file, err)
+	}
+	return nil
+}
+
+// parseRoutes reads an API in the route file format. Errors are prefixed
+// with the line number.
+func parseRoutes(r io.Reader) (*api, error) {
+	a := new(api)
+	seen := make(map[route]bool)
+	weighted := false
+
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := s.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, fmt.Errorf("%d: expected 2 or 3 fields, got %d", n, len(fields))
+		}
+
+		key, value := fields[0], fields[1]
+		if strings.HasPrefix(key, "@") {
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("%d: expected 2 fields, got %d", n, len(fields))
+			}
+			switch key {
+			case "@name":
+				a.name = value
+			case "@static":
+				a.static = value
+			case "@param":
+				a.param = value
+			case "@2params":
+				a.twoParams = value
+			default:
+				return nil, fmt.Errorf("%d: unknown metadata %s", n, key)
+			}
+			continue
+		}
+
+		r := route{key, value}
+		switch {
+		case !contains(routeMethods, r.method):
+			return nil, fmt.Errorf("%d: unsupported method %s", n, r.method)
+		case !strings.HasPrefix(r.path, "/"):
+			return nil, fmt.Errorf("%d: path %s does not start with /", n, r.path)
+		case seen[r]:
+			return nil, fmt.Errorf("%d: duplicate route %s %s", n, r.method, r.path)
+		}
+
+		weight := 1.0
+		if len(fields) == 3 {
+			var err error
+			weight, err = strconv.ParseFloat(fields[2], 64)
+			if err != nil || weight <= 0 {
+				return nil, fmt.Errorf("%d: invalid weight %s", n, fields[2])
+			}
+			weighted = true
+		}
+
+		seen[r] = true
+		a.routes = append(a.routes, r)
+		a.weights = append(a.weights, weight)
+	}
+	if !weighted {
+		a.weights = nil
+	}
+	return a, s.Err()
+}
+
+func TestParseRoutes(t *testing.T) {
+	a, err := parseRoutes(strings.NewReader(`
+# Example API
+@name   Example
+@param  /users/gordon
+
+GET    /users
+GET    /users/:user 5  # trailing comment
+DELETE /users/:user
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &api{
+		name:  "Example",
+		param: "/users/gordon",
+		routes: []route{
+			{"GET", "/users"},
+			{"GET", "/users/:user"},
+			{"DELETE", "/users/:user"},
+		},
+		weights: []float64{1, 5, 1},
+	}
+	if !reflect.DeepEqual(a, want) {
+		t.Errorf("got %+v, want %+v", a, want)
+	}
+
+	for _, in := range []string{
+		"GET",
+		"GET /users extra",
+		"GET /users 0",
+		"@name Example 1",
+		"HEAD /users",
+		"GET users",
+		"GET /users\nGET /users",
+		"@unknown value",
+	} {
+		if _, err = parseRoutes(strings.NewReader(in)); err == nil {
+			t.Errorf("expected an error for %q", in)
+		}
+	}
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var (
+	diff         = flag.Bool("diff", false, "run TestDifferential")
+	diffRef      = flag.String("diff.ref", oracleRouter.name, "`router` the answers of all other routers are compared with by TestDifferential")
+	diffExamples = flag.Int("diff.examples", 3, "maximum `number` of divergent requests listed per router and request class")
+)
+
+// diffClass is a class of requests TestDifferential sends to every router.
+type diffClass struct {
+	name string
+
+	// unmatched marks classes of requests which no route should match.
+	// They are not sent to routers with skipUnmatched.
+	unmatched bool
+
+	requests func(a *api) []route
+}
+
+// group is the name of the column of the class in the divergence report,
+// e.g. NotFound for NotFound/Divergent.
+func (c diffClass) group() string {
+	return strings.SplitN(c.name, "/", 2)[0]
+}
+
+// diffClasses returns the request classes, which are named like the
+// benchmarks of the same requests.
+func diffClasses() []diffClass {
+	classes := []diffClass{
+		{"Hit", false, func(a *api) []route {
+			requests := make([]route, len(a.routes))
+			for i, r := range a.routes {
+				requests[i] = route{r.method, a.paths[i]}
+			}
+			return requests
+		}},
+	}
+	for c, class := range missClasses {
+		c := c
+		classes = append(classes, diffClass{"NotFound/" + class.name, true, func(a *api) []route {
+			requests := make([]route, len(a.misses[c]))
+			for i, path := range a.misses[c] {
+				requests[i] = route{"GET", path}
+			}
+			return requests
+		}})
+	}
+	classes = append(classes, diffClass{"WrongMethod", true, func(a *api) []route {
+		return a.wrongMethods
+	}})
+	for c, class := range variantClasses {
+		c := c
+		classes = append(classes, diffClass{"PathVariant/" + class.name, true, func(a *api) []route {
+			requests := make([]route, len(a.variants[c]))
+			for i, v := range a.variants[c] {
+				requests[i] = v.request
+			}
+			return requests
+		}})
+	}
+	for c, class := range catchAllClasses {
+		c := c
+		classes = append(classes, diffClass{"CatchAll/" + class.name, false, func(a *api) []route {
+			return a.catchAlls[c]
+		}})
+	}
+	for c, class := range constraintClasses {
+		c := c
+		classes = append(classes, diffClass{"Constraint/" + class.name, class.unmatched, func(a *api) []route {
+			return a.constrained[c]
+		}})
+	}
+	// routers matching the decoded path find no route for some of the
+	// encoded requests, e.g. those with an encoded slash
+	for c, class := range encodingClasses {
+		c := c
+		classes = append(classes, diffClass{"Encoding/" + class.name, true, func(a *api) []route {
+			requests := make([]route, len(a.encoded[c]))
+			for i, e := range a.encoded[c] {
+				requests[i] = e.request
+			}
+			return requests
+		}})
+	}
+	return classes
+}
+
+// diffOutcome is the answer of a router to a request.
+type diffOutcome struct {
+	panicked bool
+	status   int
+
+	// route is the route whose handler served the request, if any, and
+	// params are the non-empty values of the parameters it read.
+	route  string
+	params string
+
+	// location is the target of redirects.
+	location string
+}
+
+func (o diffOutcome) String() string {
+	if o.panicked {
+		return "panic"
+	}
+	s := strconv.Itoa(o.status)
+	if o.route != "" {
+		s += " " + o.route
+	}
+	if o.params != "" {
+		s += " {" + o.params + "}"
+	}
+	if o.location != "" {
+		s += " to " + o.location
+	}
+	return s
+}
+
+// diffServe sends the request to the router, which is asked for the values
+// of the named parameters unless it has no API to read them.
+func diffServe(router *routerAdapter, h http.Handler, req route, names string) diffOutcome {
+	r := httptest.NewRequest(req.method, req.path, nil)
+	if !router.anonymousParams {
+		r.Header.Set(paramsHeader, names)
+	}
+	w := httptest.NewRecorder()
+	if panicked := serveRecover(h, w, r); panicked {
+		return diffOutcome{panicked: true}
+	}
+
+	o := diffOutcome{status: w.Code, route: w.Header().Get(routeHeader)}
+	if w.Code >= 300 && w.Code < 400 {
+		o.location = w.Header().Get("Location")
+	}
+	if o.route != "" {
+		// the request URI is followed by a name=value line per parameter
+		var params []string
+		for _, line := range strings.Split(w.Body.String(), "\n")[1:] {
+			if !strings.HasSuffix(line, "=") {
+				params = append(params, line)
+			}
+		}
+		o.params = strings.Join(params, ", ")
+	}
+	return o
+}
+
+func serveRecover(h http.Handler, w http.ResponseWriter, r *http.Request) (panicked bool) {
+	defer func() {
+		if recover() != nil {
+			panicked = true
+		}
+	}()
+	h.ServeHTTP(w, r)
+	return false
+}
+
+// routeParamNames returns the sorted names of all parameters of the routes,
+// except catch-all parameters.
+func routeParamNames(routes []route) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, r := range routes {
+		for _, m := range paramPattern.FindAllStringSubmatch(r.path, -1) {
+			if !seen[m[1]] {
+				seen[m[1]] = true
+				names = append(names, m[1])
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// diffCount is the number of requests of a class sent to a router and the
+// number of them it answered differently than the reference router.
+type diffCount struct {
+	compared, divergent int
+}
+
+// TestDifferential sends the requests of every class to all routers and
+// compares their answers, i.e. the status code, the route which served the
+// request and the parameter values it read, with those of the router given
+// by the -diff.ref flag, by default the oracle. It prints a report of the
+// divergent requests per router and request class. It only runs if the -diff
+// flag is set.
+func TestDifferential(t *testing.T) {
+	if !*diff {
+		t.Skip("differential testing is disabled, use -diff to enable it")
+	}
+	ref := routerByName(*diffRef)
+	if *diffRef == oracleRouter.name {
+		ref = oracleRouter
+	}
+	if ref == nil {
+		t.Fatalf("unknown reference router %s", *diffRef)
+	}
+
+	classes := diffClasses()
+	counts := make(map[string]map[string]*diffCount)
+	examples := make(map[string]map[string][]string)
+	for _, api := range apis {
+		if !api.supportedBy(ref) {
+			t.Logf("API %s: not supported by the reference router %s", api.name, ref.name)
+			continue
+		}
+		names := strings.Join(routeParamNames(api.routes), ",")
+		h := ref.build(api.routes, testHandler)
+
+		requests := make([][]route, len(classes))
+		want := make([][]diffOutcome, len(classes))
+		for c, class := range classes {
+			if class.unmatched && ref.skipUnmatched {
+				continue
+			}
+			requests[c] = ref.supportedRoutes(class.requests(api))
+			for _, req := range requests[c] {
+				want[c] = append(want[c], diffServe(ref, h, req, names))
+			}
+		}
+
+		for _, router := range routers {
+			if router == ref || router.skipTest || !api.supportedBy(router) {
+				continue
+			}
+			if counts[router.name] == nil {
+				counts[router.name] = make(map[string]*diffCount)
+				examples[router.name] = make(map[string][]string)
+			}
+			h := router.build(api.routes, testHandler)
+
+			for c, class := range classes {
+				if class.unmatched && router.skipUnmatched {
+					continue
+				}
+				count := counts[router.name][class.group()]
+				if count == nil {
+					count = new(diffCount)
+					counts[router.name][class.group()] = count
+				}
+				for i, req := range requests[c] {
+					if !router.handles(req.method) {
+						continue
+					}
+					got, want := diffServe(router, h, req, names), want[c][i]
+					if router.anonymousParams {
+						want.params = ""
+					} else if ref.anonymousParams {
+						got.params = ""
+					}
+					count.compared++
+					if got == want {
+						continue
+					}
+					count.divergent++
+					if len(examples[router.name][class.name]) < *diffExamples {
+						examples[router.name][class.name] = append(examples[router.name][class.name],
+							fmt.Sprintf("%s %s: %s, %s: %s", req.method, req.path, got, ref.name, want))
+					}
+				}
+			}
+		}
+	}
+
+	var groups []string
+	for _, class := range classes {
+		if !contains(groups, class.group()) {
+			groups = append(groups, class.group())
+		}
+	}
+	var rows [][]string
+	for _, router := range routers {
+		if counts[router.name] == nil {
+			continue
+		}
+		row := []string{router.name}
+		for _, group := range groups {
+			cell := "-"
+			if count := counts[router.name][group]; count != nil && count.compared > 0 {
+				cell = fmt.Sprintf("%d / %d", count.divergent, count.compared)
+			}
+			row = append(row, cell)
+		}
+		rows = append(rows, row)
+	}
+
+	fmt.Printf("\nDivergent answers compared with %s:\n\n", ref.name)
+	if err := writeMarkdownTable(os.Stdout, append([]string{"Router"}, groups...), rows); err != nil {
+		t.Fatal(err)
+	}
+	for _, router := range routers {
+		if len(examples[router.name]) == 0 {
+			continue
+		}
+		fmt.Printf("\n%s:\n", router.name)
+		for _, class := range classes {
+			for _, example := range examples[router.name][class.name] {
+				fmt.Printf("  %s: %s\n", class.name, example)
+			}
+		}
+	}
+}
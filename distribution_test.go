@@ -0,0 +1,169 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var (
+	distFlag = flag.String("dist", "uniform,zipf:1.1,weighted", "comma-separated traffic `distributions` of the Dist benchmarks: uniform, zipf[:exponent] or weighted")
+	distSeed = flag.Int64("dist.seed", 1, "`seed` of the request sequences of the Dist benchmarks")
+)
+
+// distPasses is the length of a request sequence, in multiples of the
+// number of routes of the API.
+const distPasses = 16
+
+// distribution assigns each route of an API the probability of it being
+// requested.
+type distribution struct {
+	name string
+
+	// weights returns the unnormalized weights of the routes, or nil if
+	// the distribution is not applicable to the API.
+	weights func(a *api, rnd *rand.Rand) []float64
+}
+
+// parseDistributions parses the value of the dist flag.
+func parseDistributions(s string) ([]distribution, error) {
+	var dists []distribution
+	for _, spec := range strings.Split(s, ",") {
+		spec = strings.TrimSpace(spec)
+		switch {
+		case spec == "":
+		case spec == "uniform":
+			dists = append(dists, distribution{"Uniform", uniformWeights})
+		case spec == "weighted":
+			dists = append(dists, distribution{"Weighted", func(a *api, _ *rand.Rand) []float64 {
+				return a.weights
+			}})
+		case spec == "zipf" || strings.HasPrefix(spec, "zipf:"):
+			exp := 1.0
+			if i := strings.IndexByte(spec, ':'); i >= 0 {
+				var err error
+				if exp, err = strconv.ParseFloat(spec[i+1:], 64); err != nil || exp <= 0 {
+					return nil, fmt.Errorf("invalid zipf exponent in %s", spec)
+				}
+			}
+			dists = append(dists, distribution{
+				"Zipf" + strconv.FormatFloat(exp, 'g', -1, 64),
+				func(a *api, rnd *rand.Rand) []float64 {
+					return zipfWeights(len(a.routes), exp, rnd)
+				},
+			})
+		default:
+			return nil, fmt.Errorf("unknown distribution %s", spec)
+		}
+	}
+	return dists, nil
+}
+
+func uniformWeights(a *api, _ *rand.Rand) []float64 {
+	weights := make([]float64, len(a.routes))
+	for i := range weights {
+		weights[i] = 1
+	}
+	return weights
+}
+
+// zipfWeights returns weights following Zipf's law: the route of rank k is
+// requested with a weight of 1/k^exp. The ranks are assigned at random, so
+// that the hot routes are not simply the first ones declared.
+func zipfWeights(n int, exp float64, rnd *rand.Rand) []float64 {
+	weights := make([]float64, n)
+	for i, rank := range rnd.Perm(n) {
+		weights[i] = 1 / math.Pow(float64(rank+1), exp)
+	}
+	return weights
+}
+
+// requestSequence returns a sequence of route indices drawn according to the
+// weights. It only depends on the weights and the seed.
+func requestSequence(weights []float64, n int, rnd *rand.Rand) []int {
+	cumulative := make([]float64, len(weights))
+	sum := 0.0
+	for i, w := range weights {
+		sum += w
+		cumulative[i] = sum
+	}
+
+	seq := make([]int, n)
+	for i := range seq {
+		seq[i] = sort.SearchFloat64s(cumulative, rnd.Float64()*sum)
+	}
+	return seq
+}
+
+// sequence returns the request sequence of the API in the distribution,
+// or nil if the distribution is not applicable to the API.
+func (d distribution) sequence(a *api) []int {
+	rnd := rand.New(rand.NewSource(*distSeed))
+	weights := d.weights(a, rnd)
+	if weights == nil {
+		return nil
+	}
+	return requestSequence(weights, len(a.routes)*distPasses, rnd)
+}
+
+// supportedSequence maps a sequence of indices of the routes of an API to
+// the indices of the supported routes, as returned by api.supported. Routes
+// which are not supported are dropped.
+func supportedSequence(seq []int, index []int) []int {
+	supported := make([]int, 0, len(seq))
+	for _, i := range seq {
+		if index[i] >= 0 {
+			supported = append(supported, index[i])
+		}
+	}
+	return supported
+}
+
+// benchSequence is benchRoutes with the routes requested in the order of the
+// sequence. Like in benchRoutes, an op makes as many requests as the API has
+// routes, so that the results are comparable.
+func benchSequence(b *testing.B, router http.Handler, routes []route, paths []string, seq []int) {
+	requests := make([]route, len(seq))
+	for j, i := range seq {
+		requests[j] = route{routes[i].method, paths[i]}
+	}
+	benchSent(b, router, sentRequests(requests), len(routes))
+}
+
+func TestDistributions(t *testing.T) {
+	if _, err := parseDistributions(*distFlag); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"normal", "zipf:0", "zipf:x"} {
+		if _, err := parseDistributions(s); err == nil {
+			t.Errorf("expected an error for %s", s)
+		}
+	}
+
+	dists, _ := parseDistributions("zipf:2")
+	a := &api{routes: githubAPI}
+	seq := dists[0].sequence(a)
+	if !reflect.DeepEqual(seq, dists[0].sequence(a)) {
+		t.Fatal("the same seed generated different sequences")
+	}
+
+	// the hottest route should get about 1/H(n,2) ~ 60% of the requests
+	counts := make(map[int]int)
+	max := 0
+	for _, i := range seq {
+		counts[i]++
+		if counts[i] > max {
+			max = counts[i]
+		}
+	}
+	if share := float64(max) / float64(len(seq)); share < 0.5 || share > 0.7 {
+		t.Errorf("hottest route got %.2f of the requests, expected about 0.6", share)
+	}
+}
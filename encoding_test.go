@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// encodingClasses derive a percent-encoded variant of a segment of a path.
+// encode returns the segment as it is sent and as it is decoded.
+var encodingClasses = []struct {
+	name string
+
+	// static classes encode a static segment, all others the value of an
+	// unconstrained parameter.
+	static bool
+
+	encode func(segment string) (raw, decoded string)
+}{
+	// /users/gor%2Fdon
+	{"Slash", false, insertEncoded("%2F", "/")},
+	// /users/gor%20don
+	{"Space", false, insertEncoded("%20", " ")},
+	// /users/gor+don, a plus only stands for a space in queries
+	{"Plus", false, insertEncoded("+", "+")},
+	// /users/gor%C3%A9don
+	{"Unicode", false, insertEncoded("%C3%A9", "é")},
+	// /users/gor%64on
+	{"Letter", false, encodeLetter},
+	// /us%65rs/gordon
+	{"StaticLetter", true, encodeLetter},
+}
+
+// insertEncoded returns an encode function which inserts the encoded
+// character in the middle of the segment.
+func insertEncoded(raw, decoded string) func(segment string) (string, string) {
+	return func(segment string) (string, string) {
+		i := len(segment) / 2
+		return segment[:i] + raw + segment[i:], segment[:i] + decoded + segment[i:]
+	}
+}
+
+// encodeLetter percent-encodes the first letter or digit of the segment,
+// which is decoded to the segment itself. It returns an empty raw segment if
+// there is none.
+func encodeLetter(segment string) (string, string) {
+	for i := 0; i < len(segment); i++ {
+		if c := segment[i]; 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' {
+			return segment[:i] + fmt.Sprintf("%%%02X", c) + segment[i+1:], segment
+		}
+	}
+	return "", segment
+}
+
+// encodedRequest is a request for a path of a route with a percent-encoded
+// segment.
+type encodedRequest struct {
+	request route
+
+	// path is the decoded path and rawPath the path as it is sent, if it
+	// differs from the default encoding of path, like in url.URL. plain is
+	// the path of the route without encoded characters.
+	path, rawPath string
+	plain         string
+
+	// route is the route serving the plain path.
+	route route
+
+	// param is the name of the parameter with the encoded value, which is
+	// empty for static classes, and value and rawValue are its decoded
+	// and raw value.
+	param           string
+	value, rawValue string
+}
+
+// generateEncoded generates up to classRequests requests for each encoding
+// class from the paths of the API.
+func (a *api) generateEncoded() {
+	rnd := rand.New(rand.NewSource(*paramSeed))
+	a.encoded = make([][]encodedRequest, len(encodingClasses))
+	for c, class := range encodingClasses {
+		a.sampleRoutes(rnd, func(i int) bool {
+			rt, path := a.routes[i], a.paths[i]
+			if match, _ := oracleMatch(a.routes, rt.method, path); match.path != rt.path {
+				return false
+			}
+
+			patterns, segments := strings.Split(rt.path, "/"), strings.Split(path, "/")
+			var candidates []int
+			for j, p := range patterns {
+				kind := kindOfSegment(p)
+				if segments[j] != "" && (kind == staticSegment) == class.static &&
+					(kind == staticSegment || kind == paramSegment) {
+					candidates = append(candidates, j)
+				}
+			}
+			if len(candidates) == 0 {
+				return false
+			}
+			j := candidates[rnd.Intn(len(candidates))]
+			raw, decoded := class.encode(segments[j])
+			if raw == "" {
+				return false
+			}
+
+			e := encodedRequest{plain: path, route: rt}
+			if !class.static {
+				e.param, e.value, e.rawValue = paramName(patterns[j]), decoded, raw
+			}
+			segments[j] = raw
+			e.request = route{rt.method, strings.Join(segments, "/")}
+			u, err := url.Parse(e.request.path)
+			if err != nil {
+				panic(err)
+			}
+			e.path, e.rawPath = u.Path, u.RawPath
+			a.encoded[c] = append(a.encoded[c], e)
+			return true
+		})
+	}
+}
+
+// benchEncoded makes one request per op, for the encoded path of the
+// requests or, if plain is set, for the plain path.
+func benchEncoded(b *testing.B, router http.Handler, requests []encodedRequest, plain bool) {
+	sent := make([]sentRequest, len(requests))
+	for i, e := range requests {
+		if plain {
+			sent[i] = sentRequest{method: e.request.method, requestURI: e.plain, path: e.plain}
+		} else {
+			sent[i] = sentRequest{method: e.request.method, requestURI: e.request.path, path: e.path, rawPath: e.rawPath}
+		}
+	}
+	benchSent(b, router, sent, 1)
+}
+
+// encodingOutcome classifies the answer to an encoded request. If the route
+// of the plain path served it, the outcome is whether the router yields the
+// decoded or the raw parameter value, or just that it matched if the value
+// cannot be read. For static classes, matching means that the router matches
+// the decoded path. Otherwise it is the status code or another route.
+func encodingOutcome(router *routerAdapter, w *httptest.ResponseRecorder, e encodedRequest) (outcome, value string) {
+	switch w.Header().Get(routeHeader) {
+	case "":
+		return strconv.Itoa(w.Code), ""
+	case e.route.method + " " + e.route.path:
+	default:
+		return "other route", ""
+	}
+	if e.param == "" {
+		return "decoded", ""
+	}
+	if router.anonymousParams {
+		return "matched", ""
+	}
+
+	// routers differ in the request URI they pass on, which precedes the
+	// name=value line
+	body, line := w.Body.String(), "\n"+e.param+"="
+	if i := strings.Index(body, line); i >= 0 {
+		value = body[i+len(line):]
+	}
+	switch value {
+	case e.value:
+		return "decoded", value
+	case e.rawValue:
+		return "raw", value
+	}
+	return "other value", value
+}
+
+// TestEncoding prints with -v how the routers answer requests for paths with
+// percent-encoded characters: whether they match the decoded or the raw path
+// and which value they yield for an encoded parameter. Unexpected values are
+// logged with an example per router and encoding class.
+func TestEncoding(t *testing.T) {
+	var rows [][]string
+	for _, router := range routers {
+		if router.skipTest || router.skipUnmatched {
+			continue
+		}
+
+		outcomes := classCounts(len(encodingClasses))
+		for _, api := range apis {
+			if !api.supportedBy(router) {
+				continue
+			}
+			h := router.build(api.routes, testHandler)
+
+			for c, requests := range api.encoded {
+				for _, e := range requests {
+					if !router.handles(e.request.method) {
+						continue
+					}
+					r := httptest.NewRequest(e.request.method, e.request.path, nil)
+					if !router.anonymousParams {
+						r.Header.Set(paramsHeader, e.param)
+					}
+					w := httptest.NewRecorder()
+					if serveRecover(h, w, r) {
+						outcomes[c]["panic"]++
+						continue
+					}
+
+					outcome, value := encodingOutcome(router, w, e)
+					if outcome == "other value" && outcomes[c][outcome] == 0 {
+						t.Logf("%s in API %s: %s %s yields %s=%q for route %s",
+							router.name, api.name, e.request.method, e.request.path, e.param, value, e.route.path)
+					}
+					outcomes[c][outcome]++
+				}
+			}
+		}
+
+		rows = append(rows, classRow(router.name, outcomes))
+	}
+
+	if testing.Verbose() {
+		header := []string{"Router"}
+		for _, class := range encodingClasses {
+			header = append(header, class.name)
+		}
+		if err := writeMarkdownTable(os.Stdout, header, rows); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+//go:build go1.18
+// +build go1.18
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fuzzAPIs are the names of the APIs the routers are fuzzed with.
+var fuzzAPIs = []string{"GitHub", "GPlus", "Parse", "Static"}
+
+// fuzzTimeout is the time a router may take to answer a request before it
+// is considered to hang.
+const fuzzTimeout = time.Second
+
+// fuzzHandlers are the routers loaded so far, by API and router name.
+var fuzzHandlers = make(map[string]http.Handler)
+
+func fuzzHandler(a *api, router *routerAdapter) http.Handler {
+	key := a.name + "/" + router.name
+	h, ok := fuzzHandlers[key]
+	if !ok {
+		h = router.build(a.routes, testHandler)
+		fuzzHandlers[key] = h
+	}
+	return h
+}
+
+// fuzzRequest parses the request line like a server does and reports
+// whether it is valid. Requests a server would reject never reach a router.
+func fuzzRequest(method, target string) (*http.Request, bool) {
+	if strings.ContainsAny(method+target, " \r\n") {
+		return nil, false
+	}
+	line := method + " " + target + " HTTP/1.1\r\nHost: localhost\r\n\r\n"
+	r, err := http.ReadRequest(bufio.NewReader(strings.NewReader(line)))
+	if err != nil || !strings.HasPrefix(target, "/") {
+		return nil, false
+	}
+	return r, true
+}
+
+// plainRequest reports whether the request is plain enough for the routers to
+// be expected to agree with the oracle: the request URI is the clean path,
+// without a query and without characters which are escaped in URLs. Routers
+// differ in whether they match the escaped or the unescaped path.
+func plainRequest(r *http.Request) bool {
+	p := r.URL.Path
+	return r.RequestURI == p && r.URL.EscapedPath() == p &&
+		(path.Clean(p) == p || path.Clean(p)+"/" == p)
+}
+
+// serveTimeout serves the request and reports a panic of the router as
+// error, as well as a request which was not answered within the timeout.
+func serveTimeout(h http.Handler, w http.ResponseWriter, r *http.Request, timeout time.Duration) error {
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if err := recover(); err != nil {
+				done <- fmt.Errorf("panic: %v", err)
+			}
+		}()
+		h.ServeHTTP(w, r)
+		done <- nil
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("no answer within %v", timeout)
+	}
+}
+
+// FuzzRouters sends requests with an arbitrary method and path to all
+// routers loaded with the fuzzAPIs. The routers must neither panic nor hang
+// and must serve plain requests the oracle matches with the same route and
+// parameter values. Failing inputs are saved in testdata/fuzz/FuzzRouters
+// by go test -fuzz and are run against all routers and APIs by go test.
+func FuzzRouters(f *testing.F) {
+	var fuzzed []*api
+	for _, a := range apis {
+		if contains(fuzzAPIs, a.name) {
+			fuzzed = append(fuzzed, a)
+		}
+	}
+
+	for _, a := range fuzzed {
+		for i := 0; i < len(a.routes); i += 16 {
+			f.Add(a.routes[i].method, a.paths[i])
+		}
+		for _, misses := range a.misses {
+			if len(misses) > 0 {
+				f.Add("GET", misses[0])
+			}
+		}
+		for _, variants := range a.variants {
+			if len(variants) > 0 {
+				f.Add(variants[0].request.method, variants[0].request.path)
+			}
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, method, target string) {
+		if _, ok := fuzzRequest(method, target); !ok {
+			return
+		}
+		for _, a := range fuzzed {
+			match, matched := route{}, false
+			if r, _ := fuzzRequest(method, target); plainRequest(r) {
+				match, matched = oracleMatch(a.routes, method, r.URL.Path)
+			}
+
+			for _, router := range routers {
+				if router.skipTest || !a.supportedBy(router) {
+					continue
+				}
+				// some unmatched requests are known to make them panic
+				if router.skipUnmatched && !matched ||
+					router.standardMethodsOnly && !contains(standardMethods, method) {
+					continue
+				}
+				h := fuzzHandler(a, router)
+
+				r, _ := fuzzRequest(method, target)
+				names, want := expectedParams(match.path, r.URL.Path)
+				if matched && !router.anonymousParams {
+					r.Header.Set(paramsHeader, strings.Join(names, ","))
+				}
+				w := httptest.NewRecorder()
+				if err := serveTimeout(h, w, r, fuzzTimeout); err != nil {
+					t.Fatalf("%s in API %s: %s %q: %v", router.name, a.name, method, target, err)
+				}
+
+				if !matched || !router.handles(method) ||
+					router.paramDelims != "" && !canMatch(match.path, r.URL.Path, router.paramDelims) {
+					continue
+				}
+				got := w.Header().Get(routeHeader)
+				if got != match.method+" "+match.path {
+					t.Errorf("%s in API %s: %s %q matched route %q; expected %s %s",
+						router.name, a.name, method, target, got, match.method, match.path)
+					continue
+				}
+				if router.anonymousParams {
+					continue
+				}
+				if params := strings.TrimPrefix(w.Body.String(), r.RequestURI); params != want {
+					t.Errorf("%s in API %s: %s %q for route %s has parameters %q; expected %q",
+						router.name, a.name, method, target, match.path, params, want)
+				}
+			}
+		}
+	})
+}
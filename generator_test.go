@@ -0,0 +1,142 @@
+package main
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// routeGen describes a synthetic route table. The routes form a tree in
+// which every node is a path with a single route. A node either has fanOut
+// static children or a single parameter child, so the generated tables can
+// be loaded by routers which do not allow static and parameter segments at
+// the same position.
+type routeGen struct {
+	// depth is the maximum number of segments of a path.
+	depth int
+
+	// fanOut is the number of static children of a node.
+	fanOut int
+
+	// paramFraction is the probability of a node having a parameter child
+	// instead of static children.
+	paramFraction float64
+
+	// sharedPrefix is the probability of a static segment extending the
+	// name of its previous sibling, e.g. user and users.
+	sharedPrefix float64
+
+	// methods are the methods of the routes, chosen by weight. Defaults to
+	// defaultMethodMix.
+	methods []methodWeight
+
+	// seed makes the table reproducible. The same routeGen always
+	// generates the same routes.
+	seed int64
+}
+
+type methodWeight struct {
+	method string
+	weight int
+}
+
+// defaultMethodMix roughly matches the methods of the GitHub API.
+var defaultMethodMix = []methodWeight{
+	{"GET", 6},
+	{"POST", 2},
+	{"PUT", 1},
+	{"DELETE", 1},
+}
+
+var paramNames = []string{"id", "name", "slug", "key", "ref", "user", "owner", "repo"}
+
+const segmentLetters = "abcdefghijklmnopqrstuvwxyz"
+
+// routes generates the route table.
+func (g routeGen) routes() []route {
+	methods := g.methods
+	if len(methods) == 0 {
+		methods = defaultMethodMix
+	}
+	total := 0
+	for _, m := range methods {
+		total += m.weight
+	}
+
+	rnd := rand.New(rand.NewSource(g.seed))
+	word := func(min, max int) string {
+		b := make([]byte, min+rnd.Intn(max-min+1))
+		for i := range b {
+			b[i] = segmentLetters[rnd.Intn(len(segmentLetters))]
+		}
+		return string(b)
+	}
+	method := func() string {
+		n := rnd.Intn(total)
+		for _, m := range methods {
+			if n < m.weight {
+				return m.method
+			}
+			n -= m.weight
+		}
+		return methods[len(methods)-1].method
+	}
+
+	var routes []route
+	var walk func(path string, depth int)
+	walk = func(path string, depth int) {
+		if depth > 0 {
+			routes = append(routes, route{method(), path})
+		}
+		if depth == g.depth {
+			return
+		}
+
+		if rnd.Float64() < g.paramFraction {
+			name := paramNames[rnd.Intn(len(paramNames))] + strconv.Itoa(depth)
+			walk(path+"/:"+name, depth+1)
+			return
+		}
+
+		siblings := make(map[string]bool, g.fanOut)
+		prev := ""
+		for i := 0; i < g.fanOut; i++ {
+			var segment string
+			for segment == "" || siblings[segment] {
+				if prev != "" && rnd.Float64() < g.sharedPrefix {
+					segment = prev + word(1, 3)
+				} else {
+					segment = word(3, 10)
+				}
+			}
+			siblings[segment] = true
+			prev = segment
+			walk(path+"/"+segment, depth+1)
+		}
+	}
+	walk("", 0)
+	return routes
+}
+
+func TestRouteGen(t *testing.T) {
+	g := routeGen{depth: 4, fanOut: 3, paramFraction: 0.3, sharedPrefix: 0.5, seed: 42}
+
+	routes := g.routes()
+	if !reflect.DeepEqual(routes, g.routes()) {
+		t.Fatal("the same routeGen generated different routes")
+	}
+
+	paths := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		if paths[route.path] {
+			t.Errorf("duplicate path %s", route.path)
+		}
+		paths[route.path] = true
+	}
+
+	g.seed++
+	if reflect.DeepEqual(routes, g.routes()) {
+		t.Error("different seeds generated the same routes")
+	}
+}
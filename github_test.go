@@ -4,11 +4,6 @@
 
 package main
 
-import (
-	"net/http"
-	"testing"
-)
-
 // http://developer.github.com/v3/
 var githubAPI = []route{
 	// OAuth Authorizations
@@ -16,7 +11,7 @@ var githubAPI = []route{
 	{"GET", "/authorizations/:id"},
 	{"POST", "/authorizations"},
 	//{"PUT", "/authorizations/clients/:client_id"},
-	//{"PATCH", "/authorizations/:id"},
+	{"PATCH", "/authorizations/:id"},
 	{"DELETE", "/authorizations/:id"},
 	{"GET", "/applications/:client_id/tokens/:access_token"},
 	{"DELETE", "/applications/:client_id/tokens"},
@@ -38,7 +33,7 @@ var githubAPI = []route{
 	{"PUT", "/notifications"},
 	{"PUT", "/repos/:owner/:repo/notifications"},
 	{"GET", "/notifications/threads/:id"},
-	//{"PATCH", "/notifications/threads/:id"},
+	{"PATCH", "/notifications/threads/:id"},
 	{"GET", "/notifications/threads/:id/subscription"},
 	{"PUT", "/notifications/threads/:id/subscription"},
 	{"DELETE", "/notifications/threads/:id/subscription"},
@@ -65,7 +60,7 @@ var githubAPI = []route{
 	//{"GET", "/gists/starred"},
 	{"GET", "/gists/:id"},
 	{"POST", "/gists"},
-	//{"PATCH", "/gists/:id"},
+	{"PATCH", "/gists/:id"},
 	{"PUT", "/gists/:id/star"},
 	{"DELETE", "/gists/:id/star"},
 	{"GET", "/gists/:id/star"},
@@ -94,7 +89,7 @@ var githubAPI = []route{
 	{"GET", "/repos/:owner/:repo/issues"},
 	{"GET", "/repos/:owner/:repo/issues/:number"},
 	{"POST", "/repos/:owner/:repo/issues"},
-	//{"PATCH", "/repos/:owner/:repo/issues/:number"},
+	{"PATCH", "/repos/:owner/:repo/issues/:number"},
 	{"GET", "/repos/:owner/:repo/assignees"},
 	{"GET", "/repos/:owner/:repo/assignees/:assignee"},
 	{"GET", "/repos/:owner/:repo/issues/:number/comments"},
@@ -109,7 +104,7 @@ var githubAPI = []route{
 	{"GET", "/repos/:owner/:repo/labels"},
 	{"GET", "/repos/:owner/:repo/labels/:name"},
 	{"POST", "/repos/:owner/:repo/labels"},
-	//{"PATCH", "/repos/:owner/:repo/labels/:name"},
+	{"PATCH", "/repos/:owner/:repo/labels/:name"},
 	{"DELETE", "/repos/:owner/:repo/labels/:name"},
 	{"GET", "/repos/:owner/:repo/issues/:number/labels"},
 	{"POST", "/repos/:owner/:repo/issues/:number/labels"},
@@ -120,7 +115,7 @@ var githubAPI = []route{
 	{"GET", "/repos/:owner/:repo/milestones"},
 	{"GET", "/repos/:owner/:repo/milestones/:number"},
 	{"POST", "/repos/:owner/:repo/milestones"},
-	//{"PATCH", "/repos/:owner/:repo/milestones/:number"},
+	{"PATCH", "/repos/:owner/:repo/milestones/:number"},
 	{"DELETE", "/repos/:owner/:repo/milestones/:number"},
 
 	// Miscellaneous
@@ -136,7 +131,7 @@ var githubAPI = []route{
 	{"GET", "/users/:user/orgs"},
 	{"GET", "/user/orgs"},
 	{"GET", "/orgs/:org"},
-	//{"PATCH", "/orgs/:org"},
+	{"PATCH", "/orgs/:org"},
 	{"GET", "/orgs/:org/members"},
 	{"GET", "/orgs/:org/members/:user"},
 	{"DELETE", "/orgs/:org/members/:user"},
@@ -147,7 +142,7 @@ var githubAPI = []route{
 	{"GET", "/orgs/:org/teams"},
 	{"GET", "/teams/:id"},
 	{"POST", "/orgs/:org/teams"},
-	//{"PATCH", "/teams/:id"},
+	{"PATCH", "/teams/:id"},
 	{"DELETE", "/teams/:id"},
 	{"GET", "/teams/:id/members"},
 	{"GET", "/teams/:id/members/:user"},
@@ -163,7 +158,7 @@ var githubAPI = []route{
 	{"GET", "/repos/:owner/:repo/pulls"},
 	{"GET", "/repos/:owner/:repo/pulls/:number"},
 	{"POST", "/repos/:owner/:repo/pulls"},
-	//{"PATCH", "/repos/:owner/:repo/pulls/:number"},
+	{"PATCH", "/repos/:owner/:repo/pulls/:number"},
 	{"GET", "/repos/:owner/:repo/pulls/:number/commits"},
 	{"GET", "/repos/:owner/:repo/pulls/:number/files"},
 	{"GET", "/repos/:owner/:repo/pulls/:number/merge"},
@@ -183,7 +178,7 @@ var githubAPI = []route{
 	{"POST", "/user/repos"},
 	{"POST", "/orgs/:org/repos"},
 	{"GET", "/repos/:owner/:repo"},
-	//{"PATCH", "/repos/:owner/:repo"},
+	{"PATCH", "/repos/:owner/:repo"},
 	{"GET", "/repos/:owner/:repo/contributors"},
 	{"GET", "/repos/:owner/:repo/languages"},
 	{"GET", "/repos/:owner/:repo/teams"},
@@ -199,7 +194,7 @@ var githubAPI = []route{
 	{"GET", "/repos/:owner/:repo/commits/:sha/comments"},
 	{"POST", "/repos/:owner/:repo/commits/:sha/comments"},
 	{"GET", "/repos/:owner/:repo/comments/:id"},
-	//{"PATCH", "/repos/:owner/:repo/comments/:id"},
+	{"PATCH", "/repos/:owner/:repo/comments/:id"},
 	{"DELETE", "/repos/:owner/:repo/comments/:id"},
 	{"GET", "/repos/:owner/:repo/commits"},
 	{"GET", "/repos/:owner/:repo/commits/:sha"},
@@ -211,7 +206,7 @@ var githubAPI = []route{
 	{"GET", "/repos/:owner/:repo/keys"},
 	{"GET", "/repos/:owner/:repo/keys/:id"},
 	{"POST", "/repos/:owner/:repo/keys"},
-	//{"PATCH", "/repos/:owner/:repo/keys/:id"},
+	{"PATCH", "/repos/:owner/:repo/keys/:id"},
 	{"DELETE", "/repos/:owner/:repo/keys/:id"},
 	{"GET", "/repos/:owner/:repo/downloads"},
 	{"GET", "/repos/:owner/:repo/downloads/:id"},
@@ -221,14 +216,14 @@ var githubAPI = []route{
 	{"GET", "/repos/:owner/:repo/hooks"},
 	{"GET", "/repos/:owner/:repo/hooks/:id"},
 	{"POST", "/repos/:owner/:repo/hooks"},
-	//{"PATCH", "/repos/:owner/:repo/hooks/:id"},
+	{"PATCH", "/repos/:owner/:repo/hooks/:id"},
 	{"POST", "/repos/:owner/:repo/hooks/:id/tests"},
 	{"DELETE", "/repos/:owner/:repo/hooks/:id"},
 	{"POST", "/repos/:owner/:repo/merges"},
 	{"GET", "/repos/:owner/:repo/releases"},
 	{"GET", "/repos/:owner/:repo/releases/:id"},
 	{"POST", "/repos/:owner/:repo/releases"},
-	//{"PATCH", "/repos/:owner/:repo/releases/:id"},
+	{"PATCH", "/repos/:owner/:repo/releases/:id"},
 	{"DELETE", "/repos/:owner/:repo/releases/:id"},
 	{"GET", "/repos/:owner/:repo/releases/:id/assets"},
 	{"GET", "/repos/:owner/:repo/stats/contributors"},
@@ -252,7 +247,7 @@ var githubAPI = []route{
 	// Users
 	{"GET", "/users/:user"},
 	{"GET", "/user"},
-	//{"PATCH", "/user"},
+	{"PATCH", "/user"},
 	{"GET", "/users"},
 	{"GET", "/user/emails"},
 	{"POST", "/user/emails"},
@@ -269,523 +264,6 @@ var githubAPI = []route{
 	{"GET", "/user/keys"},
 	{"GET", "/user/keys/:id"},
 	{"POST", "/user/keys"},
-	//{"PATCH", "/user/keys/:id"},
+	{"PATCH", "/user/keys/:id"},
 	{"DELETE", "/user/keys/:id"},
 }
-
-var (
-	githubAce             http.Handler
-	githubAero            http.Handler
-	githubBear            http.Handler
-	githubBeego           http.Handler
-	githubBone            http.Handler
-	githubChi             http.Handler
-	githubCloudyKitRouter http.Handler
-	githubDenco           http.Handler
-	githubEcho            http.Handler
-	githubGin             http.Handler
-	githubGocraftWeb      http.Handler
-	githubGoji            http.Handler
-	githubGojiv2          http.Handler
-	githubGoJsonRest      http.Handler
-	githubGoRestful       http.Handler
-	githubGorillaMux      http.Handler
-	githubGowwwRouter     http.Handler
-	githubHttpRouter      http.Handler
-	githubHttpTreeMux     http.Handler
-	githubKocha           http.Handler
-	githubLARS            http.Handler
-	githubMacaron         http.Handler
-	githubMartini         http.Handler
-	githubPat             http.Handler
-	githubPossum          http.Handler
-	githubR2router        http.Handler
-	githubRevel           http.Handler
-	githubRivet           http.Handler
-	githubTango           http.Handler
-	githubTigerTonic      http.Handler
-	githubTraffic         http.Handler
-	githubVulcan          http.Handler
-	// githubZeus        http.Handler
-)
-
-func init() {
-	println("#GithubAPI Routes:", len(githubAPI))
-
-	calcMem("Ace", func() {
-		githubAce = loadAce(githubAPI)
-	})
-	calcMem("Aero", func() {
-		githubAero = loadAero(githubAPI)
-	})
-	calcMem("Bear", func() {
-		githubBear = loadBear(githubAPI)
-	})
-	calcMem("Beego", func() {
-		githubBeego = loadBeego(githubAPI)
-	})
-	calcMem("Bone", func() {
-		githubBone = loadBone(githubAPI)
-	})
-	calcMem("Chi", func() {
-		githubChi = loadChi(githubAPI)
-	})
-	calcMem("CloudyKitRouter", func() {
-		githubCloudyKitRouter = loadCloudyKitRouter(githubAPI)
-	})
-	calcMem("Denco", func() {
-		githubDenco = loadDenco(githubAPI)
-	})
-	calcMem("Echo", func() {
-		githubEcho = loadEcho(githubAPI)
-	})
-	calcMem("Gin", func() {
-		githubGin = loadGin(githubAPI)
-	})
-	calcMem("GocraftWeb", func() {
-		githubGocraftWeb = loadGocraftWeb(githubAPI)
-	})
-	calcMem("Goji", func() {
-		githubGoji = loadGoji(githubAPI)
-	})
-	calcMem("Gojiv2", func() {
-		githubGojiv2 = loadGojiv2(githubAPI)
-	})
-	calcMem("GoJsonRest", func() {
-		githubGoJsonRest = loadGoJsonRest(githubAPI)
-	})
-	calcMem("GoRestful", func() {
-		githubGoRestful = loadGoRestful(githubAPI)
-	})
-	calcMem("GorillaMux", func() {
-		githubGorillaMux = loadGorillaMux(githubAPI)
-	})
-	calcMem("GowwwRouter", func() {
-		githubGowwwRouter = loadGowwwRouter(githubAPI)
-	})
-	calcMem("HttpRouter", func() {
-		githubHttpRouter = loadHttpRouter(githubAPI)
-	})
-	calcMem("HttpTreeMux", func() {
-		githubHttpTreeMux = loadHttpTreeMux(githubAPI)
-	})
-	calcMem("Kocha", func() {
-		githubKocha = loadKocha(githubAPI)
-	})
-	calcMem("LARS", func() {
-		githubLARS = loadLARS(githubAPI)
-	})
-	calcMem("Macaron", func() {
-		githubMacaron = loadMacaron(githubAPI)
-	})
-	calcMem("Martini", func() {
-		githubMartini = loadMartini(githubAPI)
-	})
-	calcMem("Pat", func() {
-		githubPat = loadPat(githubAPI)
-	})
-	calcMem("Possum", func() {
-		githubPossum = loadPossum(githubAPI)
-	})
-	calcMem("R2router", func() {
-		githubR2router = loadR2router(githubAPI)
-	})
-	// calcMem("Revel", func() {
-	// 	githubRevel = loadRevel(githubAPI)
-	// })
-	calcMem("Rivet", func() {
-		githubRivet = loadRivet(githubAPI)
-	})
-	calcMem("Tango", func() {
-		githubTango = loadTango(githubAPI)
-	})
-	calcMem("TigerTonic", func() {
-		githubTigerTonic = loadTigerTonic(githubAPI)
-	})
-	calcMem("Traffic", func() {
-		githubTraffic = loadTraffic(githubAPI)
-	})
-	calcMem("Vulcan", func() {
-		githubVulcan = loadVulcan(githubAPI)
-	})
-	// calcMem("Zeus", func() {
-	// 	githubZeus = loadZeus(githubAPI)
-	// })
-
-	println()
-}
-
-// Static
-func BenchmarkAce_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubAce, req)
-}
-func BenchmarkAero_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubAero, req)
-}
-func BenchmarkBear_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubBear, req)
-}
-func BenchmarkBeego_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubBeego, req)
-}
-func BenchmarkBone_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubBone, req)
-}
-func BenchmarkCloudyKitRouter_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubCloudyKitRouter, req)
-}
-func BenchmarkChi_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubChi, req)
-}
-func BenchmarkDenco_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubDenco, req)
-}
-func BenchmarkEcho_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubEcho, req)
-}
-func BenchmarkGin_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubGin, req)
-}
-func BenchmarkGocraftWeb_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubGocraftWeb, req)
-}
-func BenchmarkGoji_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubGoji, req)
-}
-func BenchmarkGojiv2_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubGojiv2, req)
-}
-func BenchmarkGoRestful_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubGoRestful, req)
-}
-func BenchmarkGoJsonRest_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubGoJsonRest, req)
-}
-func BenchmarkGorillaMux_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubGorillaMux, req)
-}
-func BenchmarkGowwwRouter_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubGowwwRouter, req)
-}
-func BenchmarkHttpRouter_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubHttpRouter, req)
-}
-func BenchmarkHttpTreeMux_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubHttpTreeMux, req)
-}
-func BenchmarkKocha_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubKocha, req)
-}
-func BenchmarkLARS_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubLARS, req)
-}
-func BenchmarkMacaron_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubMacaron, req)
-}
-func BenchmarkMartini_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubMartini, req)
-}
-func BenchmarkPat_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubPat, req)
-}
-func BenchmarkPossum_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubPossum, req)
-}
-func BenchmarkR2router_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubR2router, req)
-}
-
-// func BenchmarkRevel_GithubStatic(b *testing.B) {
-// 	req, _ := http.NewRequest("GET", "/user/repos", nil)
-// 	benchRequest(b, githubRevel, req)
-// }
-func BenchmarkRivet_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubRivet, req)
-}
-func BenchmarkTango_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubTango, req)
-}
-func BenchmarkTigerTonic_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubTigerTonic, req)
-}
-func BenchmarkTraffic_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubTraffic, req)
-}
-func BenchmarkVulcan_GithubStatic(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/user/repos", nil)
-	benchRequest(b, githubVulcan, req)
-}
-
-// func BenchmarkZeus_GithubStatic(b *testing.B) {
-// 	req, _ := http.NewRequest("GET", "/user/repos", nil)
-// 	benchRequest(b, githubZeus, req)
-// }
-
-// Param
-func BenchmarkAce_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubAce, req)
-}
-func BenchmarkAero_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubAero, req)
-}
-func BenchmarkBear_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubBear, req)
-}
-func BenchmarkBeego_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubBeego, req)
-}
-func BenchmarkBone_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubBone, req)
-}
-func BenchmarkChi_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubChi, req)
-}
-func BenchmarkCloudyKitRouter_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubCloudyKitRouter, req)
-}
-func BenchmarkDenco_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubDenco, req)
-}
-func BenchmarkEcho_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubEcho, req)
-}
-func BenchmarkGin_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubGin, req)
-}
-func BenchmarkGocraftWeb_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubGocraftWeb, req)
-}
-func BenchmarkGoji_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubGoji, req)
-}
-func BenchmarkGojiv2_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubGojiv2, req)
-}
-func BenchmarkGoJsonRest_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubGoJsonRest, req)
-}
-func BenchmarkGoRestful_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubGoRestful, req)
-}
-func BenchmarkGorillaMux_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubGorillaMux, req)
-}
-func BenchmarkGowwwRouter_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubGowwwRouter, req)
-}
-func BenchmarkHttpRouter_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubHttpRouter, req)
-}
-func BenchmarkHttpTreeMux_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubHttpTreeMux, req)
-}
-func BenchmarkKocha_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubKocha, req)
-}
-func BenchmarkLARS_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubLARS, req)
-}
-func BenchmarkMacaron_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubMacaron, req)
-}
-func BenchmarkMartini_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubMartini, req)
-}
-func BenchmarkPat_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubPat, req)
-}
-func BenchmarkPossum_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubPossum, req)
-}
-func BenchmarkR2router_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubR2router, req)
-}
-
-// func BenchmarkRevel_GithubParam(b *testing.B) {
-// 	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-// 	benchRequest(b, githubRevel, req)
-// }
-func BenchmarkRivet_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubRivet, req)
-}
-func BenchmarkTango_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubTango, req)
-}
-func BenchmarkTigerTonic_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubTigerTonic, req)
-}
-func BenchmarkTraffic_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubTraffic, req)
-}
-func BenchmarkVulcan_GithubParam(b *testing.B) {
-	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-	benchRequest(b, githubVulcan, req)
-}
-
-// func BenchmarkZeus_GithubParam(b *testing.B) {
-// 	req, _ := http.NewRequest("GET", "/repos/julienschmidt/httprouter/stargazers", nil)
-// 	benchRequest(b, githubZeus, req)
-// }
-
-// All routes
-func BenchmarkAce_GithubAll(b *testing.B) {
-	benchRoutes(b, githubAce, githubAPI)
-}
-func BenchmarkAero_GithubAll(b *testing.B) {
-	benchRoutes(b, githubAero, githubAPI)
-}
-func BenchmarkBear_GithubAll(b *testing.B) {
-	benchRoutes(b, githubBear, githubAPI)
-}
-func BenchmarkBeego_GithubAll(b *testing.B) {
-	benchRoutes(b, githubBeego, githubAPI)
-}
-func BenchmarkBone_GithubAll(b *testing.B) {
-	benchRoutes(b, githubBone, githubAPI)
-}
-func BenchmarkChi_GithubAll(b *testing.B) {
-	benchRoutes(b, githubChi, githubAPI)
-}
-func BenchmarkCloudyKitRouter_GithubAll(b *testing.B) {
-	benchRoutes(b, githubCloudyKitRouter, githubAPI)
-}
-func BenchmarkDenco_GithubAll(b *testing.B) {
-	benchRoutes(b, githubDenco, githubAPI)
-}
-func BenchmarkEcho_GithubAll(b *testing.B) {
-	benchRoutes(b, githubEcho, githubAPI)
-}
-func BenchmarkGin_GithubAll(b *testing.B) {
-	benchRoutes(b, githubGin, githubAPI)
-}
-func BenchmarkGocraftWeb_GithubAll(b *testing.B) {
-	benchRoutes(b, githubGocraftWeb, githubAPI)
-}
-func BenchmarkGoji_GithubAll(b *testing.B) {
-	benchRoutes(b, githubGoji, githubAPI)
-}
-func BenchmarkGojiv2_GithubAll(b *testing.B) {
-	benchRoutes(b, githubGojiv2, githubAPI)
-}
-func BenchmarkGoJsonRest_GithubAll(b *testing.B) {
-	benchRoutes(b, githubGoJsonRest, githubAPI)
-}
-func BenchmarkGoRestful_GithubAll(b *testing.B) {
-	benchRoutes(b, githubGoRestful, githubAPI)
-}
-func BenchmarkGorillaMux_GithubAll(b *testing.B) {
-	benchRoutes(b, githubGorillaMux, githubAPI)
-}
-func BenchmarkGowwwRouter_GithubAll(b *testing.B) {
-	benchRoutes(b, githubGowwwRouter, githubAPI)
-}
-func BenchmarkHttpRouter_GithubAll(b *testing.B) {
-	benchRoutes(b, githubHttpRouter, githubAPI)
-}
-func BenchmarkHttpTreeMux_GithubAll(b *testing.B) {
-	benchRoutes(b, githubHttpTreeMux, githubAPI)
-}
-func BenchmarkKocha_GithubAll(b *testing.B) {
-	benchRoutes(b, githubKocha, githubAPI)
-}
-func BenchmarkLARS_GithubAll(b *testing.B) {
-	benchRoutes(b, githubLARS, githubAPI)
-}
-func BenchmarkMacaron_GithubAll(b *testing.B) {
-	benchRoutes(b, githubMacaron, githubAPI)
-}
-func BenchmarkMartini_GithubAll(b *testing.B) {
-	benchRoutes(b, githubMartini, githubAPI)
-}
-func BenchmarkPat_GithubAll(b *testing.B) {
-	benchRoutes(b, githubPat, githubAPI)
-}
-func BenchmarkPossum_GithubAll(b *testing.B) {
-	benchRoutes(b, githubPossum, githubAPI)
-}
-func BenchmarkR2router_GithubAll(b *testing.B) {
-	benchRoutes(b, githubR2router, githubAPI)
-}
-
-// func BenchmarkRevel_GithubAll(b *testing.B) {
-// 	benchRoutes(b, githubRevel, githubAPI)
-// }
-func BenchmarkRivet_GithubAll(b *testing.B) {
-	benchRoutes(b, githubRivet, githubAPI)
-}
-func BenchmarkTango_GithubAll(b *testing.B) {
-	benchRoutes(b, githubTango, githubAPI)
-}
-func BenchmarkTigerTonic_GithubAll(b *testing.B) {
-	benchRoutes(b, githubTigerTonic, githubAPI)
-}
-func BenchmarkTraffic_GithubAll(b *testing.B) {
-	benchRoutes(b, githubTraffic, githubAPI)
-}
-func BenchmarkVulcan_GithubAll(b *testing.B) {
-	benchRoutes(b, githubVulcan, githubAPI)
-}
-
-// func BenchmarkZeus_GithubAll(b *testing.B) {
-// 	benchRoutes(b, githubZeus, githubAPI)
-// }
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// generateHeadOptions generates HEAD requests for all GET routes and OPTIONS
+// requests for all paths of the API. No router is loaded with HEAD or
+// OPTIONS routes, so only automatic handling is measured.
+func (a *api) generateHeadOptions() {
+	a.heads, a.options = nil, nil
+	seen := make(map[string]bool)
+	for i, path := range a.paths {
+		if a.routes[i].method == "GET" {
+			a.heads = append(a.heads, route{"HEAD", path})
+		}
+		if !seen[path] {
+			seen[path] = true
+			a.options = append(a.options, route{"OPTIONS", path})
+		}
+	}
+}
+
+// TestHeadOptions prints with -v how the routers answer HEAD requests for GET
+// routes and OPTIONS requests, which no router has routes for: the status
+// codes, the headers set in answers to OPTIONS and whether their Allow header
+// lists the methods of the path.
+func TestHeadOptions(t *testing.T) {
+	var rows [][]string
+	for _, router := range routers {
+		if router.skipTest {
+			continue
+		}
+
+		heads := make(map[string]int)
+		options := make(map[string]int)
+		allows := make(map[string]int)
+		headers := make(map[string]bool)
+		for _, api := range apis {
+			if !api.supportedBy(router) {
+				continue
+			}
+			h := router.build(api.routes, testHandler)
+
+			for _, req := range api.heads {
+				r, _ := http.NewRequest(req.method, req.path, nil)
+				w := httptest.NewRecorder()
+				h.ServeHTTP(w, r)
+				heads[strconv.Itoa(w.Code)]++
+			}
+
+			for _, req := range api.options {
+				r, _ := http.NewRequest(req.method, req.path, nil)
+				w := httptest.NewRecorder()
+				h.ServeHTTP(w, r)
+				options[strconv.Itoa(w.Code)]++
+				if w.Code >= 300 {
+					continue
+				}
+				for name := range w.Header() {
+					headers[name] = true
+				}
+				switch allow := w.Header().Get("Allow"); {
+				case allow == "":
+					allows["missing"]++
+				case allowCorrect(allow, api.allowed(req.path)):
+					allows["correct"]++
+				default:
+					allows["wrong"]++
+				}
+			}
+		}
+		if len(heads)+len(options) == 0 {
+			continue
+		}
+
+		names := make([]string, 0, len(headers))
+		for name := range headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if len(names) == 0 {
+			names = []string{"-"}
+		}
+		allow := "-"
+		if len(allows) > 0 {
+			allow = countCells(allows)
+		}
+		rows = append(rows, []string{
+			router.name,
+			countCells(heads),
+			countCells(options),
+			strings.Join(names, ", "),
+			allow,
+		})
+	}
+
+	if testing.Verbose() {
+		header := []string{"Router", "HEAD", "OPTIONS", "OPTIONS Headers", "Allow"}
+		if err := writeMarkdownTable(os.Stdout, header, rows); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,377 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	memJSON     = flag.String("mem.json", "", "write the memory consumption report as JSON to `file`")
+	memMarkdown = flag.String("mem.md", "", "write the memory consumption report as markdown table to `file`")
+	memPrecise  = flag.Bool("mem.precise", false, "measure the memory of routers with repeated samples of multiple copies, which takes a while")
+)
+
+const (
+	// memSamples is the number of samples taken per measurement with
+	// -mem.precise.
+	memSamples = 5
+
+	// memMinBytes is the minimum number of bytes a sample should retain.
+	// Small routers are loaded multiple times per sample to reach it, so
+	// that they are not lost in the noise of the runtime's own allocations.
+	memMinBytes = 1 << 20
+
+	// memMaxCopies limits the number of routers loaded per sample.
+	memMaxCopies = 256
+
+	// memMaxSpread is the maximum relative spread of the retained bytes
+	// of all samples for a measurement to be considered stable.
+	memMaxSpread = 0.01
+)
+
+// memStat is the memory required for loading a router with an API. All
+// values are the median of the samples taken, per router.
+type memStat struct {
+	Router string `json:"router"`
+	API    string `json:"api"`
+	Routes int    `json:"routes"`
+
+	// Bytes and Objects are retained by the router after construction.
+	Bytes   int64 `json:"bytes"`
+	Objects int64 `json:"objects"`
+
+	// Mallocs and TotalAlloc are allocated during construction, including
+	// the Garbage produced, which is TotalAlloc less the retained Bytes.
+	Mallocs    int64 `json:"mallocs"`
+	TotalAlloc int64 `json:"total_alloc"`
+	Garbage    int64 `json:"garbage"`
+
+	BytesPerRoute float64 `json:"bytes_per_route"`
+
+	// BuildNs is the time it takes to construct the router.
+	BuildNs int64 `json:"build_ns"`
+
+	// Samples is the number of samples taken, each loading Copies routers.
+	// Spread is the relative difference between the largest and smallest
+	// retained bytes of all samples. A single sample is unchecked, so it is
+	// never Stable.
+	Samples int     `json:"samples"`
+	Copies  int     `json:"copies"`
+	Spread  float64 `json:"spread"`
+	Stable  bool    `json:"stable"`
+}
+
+// memReport collects the memory consumption of all routers loaded so far.
+var memReport []memStat
+
+// memSample is the memory of a single sample, per router.
+type memSample struct {
+	bytes, objects, mallocs, totalAlloc int64
+	nanos                               int64
+}
+
+// sampleMem loads copies routers at once and returns the memory they
+// retain and allocate and the time it took, divided by the number of copies.
+func sampleMem(load func() http.Handler, copies int) memSample {
+	handlers := make([]http.Handler, copies)
+	var before, loaded, after runtime.MemStats
+
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	start := time.Now()
+	for i := range handlers {
+		handlers[i] = load()
+	}
+	nanos := time.Since(start).Nanoseconds()
+	runtime.ReadMemStats(&loaded)
+
+	// everything not referenced by the routers anymore is garbage now
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(handlers)
+
+	n := int64(copies)
+	return memSample{
+		bytes:      (int64(after.HeapAlloc) - int64(before.HeapAlloc)) / n,
+		objects:    (int64(after.HeapObjects) - int64(before.HeapObjects)) / n,
+		mallocs:    int64(loaded.Mallocs-before.Mallocs) / n,
+		totalAlloc: int64(loaded.TotalAlloc-before.TotalAlloc) / n,
+		nanos:      nanos / n,
+	}
+}
+
+// calcMem measures the memory consumption of the router returned by load,
+// adds it to the memReport and returns the router.
+func calcMem(router, api string, routes int, load func() http.Handler) http.Handler {
+	stat, h := measureMem(load)
+	stat.Router = router
+	stat.API = api
+	stat.Routes = routes
+	if routes > 0 {
+		stat.BytesPerRoute = float64(stat.Bytes) / float64(routes)
+	}
+	if stat.Samples > 1 && !stat.Stable {
+		fmt.Fprintf(os.Stderr, "memory of %s (%s) is unstable: %.1f%% spread\n", router, api, stat.Spread*100)
+	}
+	memReport = append(memReport, stat)
+	return h
+}
+
+// measureMem measures the memory consumption and construction time of the
+// router returned by load and returns them together with the router. load
+// is called multiple times and must return a new router each time.
+//
+// The retained memory is the growth of the heap after a garbage collection,
+// while the transient allocations are taken from the total allocation
+// counters of the runtime. The first router is loaded before measuring, so
+// one-time initializations of the router packages are not accounted for.
+//
+// Only a single router is measured, unless the -mem.precise flag is set.
+// Then the measurement is repeated and small routers are loaded multiple
+// times per sample.
+func measureMem(load func() http.Handler) (memStat, http.Handler) {
+	h := load()
+
+	s := sampleMem(load, 1)
+	if !*memPrecise {
+		return newMemStat([]memSample{s}, 1, 0), h
+	}
+
+	copies := 1
+	if s.bytes > 0 && s.bytes < memMinBytes {
+		copies = int(memMinBytes / s.bytes)
+		if copies > memMaxCopies {
+			copies = memMaxCopies
+		}
+	}
+
+	var samples []memSample
+	var spread float64
+	for {
+		samples = samples[:0]
+		for i := 0; i < memSamples; i++ {
+			samples = append(samples, sampleMem(load, copies))
+		}
+
+		spread = memSpread(samples)
+		if spread <= memMaxSpread || copies >= memMaxCopies {
+			break
+		}
+		// more copies per sample to reduce the noise
+		copies *= 4
+		if copies > memMaxCopies {
+			copies = memMaxCopies
+		}
+	}
+
+	return newMemStat(samples, copies, spread), h
+}
+
+// newMemStat returns the median of the samples.
+func newMemStat(samples []memSample, copies int, spread float64) memStat {
+	stat := memStat{
+		Bytes:      memMedian(samples, func(s memSample) int64 { return s.bytes }),
+		Objects:    memMedian(samples, func(s memSample) int64 { return s.objects }),
+		Mallocs:    memMedian(samples, func(s memSample) int64 { return s.mallocs }),
+		TotalAlloc: memMedian(samples, func(s memSample) int64 { return s.totalAlloc }),
+		BuildNs:    memMedian(samples, func(s memSample) int64 { return s.nanos }),
+		Samples:    len(samples),
+		Copies:     copies,
+		Spread:     spread,
+		Stable:     len(samples) > 1 && spread <= memMaxSpread,
+	}
+	stat.Garbage = stat.TotalAlloc - stat.Bytes
+	return stat
+}
+
+func memMedian(samples []memSample, value func(memSample) int64) int64 {
+	values := make([]int64, len(samples))
+	for i, s := range samples {
+		values[i] = value(s)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	return values[len(values)/2]
+}
+
+func memSpread(samples []memSample) float64 {
+	min, max := samples[0].bytes, samples[0].bytes
+	for _, s := range samples[1:] {
+		if s.bytes < min {
+			min = s.bytes
+		}
+		if s.bytes > max {
+			max = s.bytes
+		}
+	}
+	median := memMedian(samples, func(s memSample) int64 { return s.bytes })
+	if median <= 0 {
+		if max == min {
+			return 0
+		}
+		return 1
+	}
+	return float64(max-min) / float64(median)
+}
+
+// writeMemReport writes the collected memory consumption report to the
+// files given by the -mem.json and -mem.md flags. If neither is set, the
+// markdown table is printed to stdout with -v, where it would otherwise mix
+// with the benchmark results.
+func writeMemReport() error {
+	if len(memReport) == 0 {
+		return nil
+	}
+
+	if *memJSON == "" && *memMarkdown == "" {
+		if !testing.Verbose() {
+			return nil
+		}
+		fmt.Println()
+		return writeMemMarkdown(os.Stdout, memReport)
+	}
+	if *memJSON != "" {
+		err := writeFile(*memJSON, func(w io.Writer) error {
+			return writeJSON(w, memReport)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if *memMarkdown != "" {
+		err := writeFile(*memMarkdown, func(w io.Writer) error {
+			return writeMemMarkdown(w, memReport)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeFile(name string, write func(io.Writer) error) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err = write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// writeMemMarkdown writes the retained bytes as table in the format used by
+// the README, one row per router and one column per API. The best 3 values
+// of each column are bold.
+func writeMemMarkdown(w io.Writer, stats []memStat) error {
+	var routerNames, apiNames []string
+	bytes := make(map[string]map[string]int64)
+	for _, s := range stats {
+		if bytes[s.Router] == nil {
+			bytes[s.Router] = make(map[string]int64)
+			routerNames = append(routerNames, s.Router)
+		}
+		if !contains(apiNames, s.API) {
+			apiNames = append(apiNames, s.API)
+		}
+		bytes[s.Router][s.API] = s.Bytes
+	}
+
+	// best 3 values of each API
+	best := make(map[string]map[string]bool)
+	for _, api := range apiNames {
+		var values []int64
+		for _, router := range routerNames {
+			if b, ok := bytes[router][api]; ok {
+				values = append(values, b)
+			}
+		}
+		sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+		if len(values) > 3 {
+			values = values[:3]
+		}
+		best[api] = make(map[string]bool)
+		for _, router := range routerNames {
+			if b, ok := bytes[router][api]; ok && b <= values[len(values)-1] {
+				best[api][router] = true
+			}
+		}
+	}
+
+	rows := make([][]string, len(routerNames))
+	for i, router := range routerNames {
+		rows[i] = []string{router}
+		for _, api := range apiNames {
+			cell := "-  "
+			if b, ok := bytes[router][api]; ok {
+				cell = fmt.Sprintf("%d B", b)
+				if best[api][router] {
+					cell = "__" + cell + "__"
+				} else {
+					cell += "  "
+				}
+			}
+			rows[i] = append(rows[i], cell)
+		}
+	}
+	return writeMarkdownTable(w, append([]string{"Router"}, apiNames...), rows)
+}
+
+// writeMarkdownTable writes a table with a left aligned first column and
+// right aligned other columns.
+func writeMarkdownTable(w io.Writer, header []string, rows [][]string) error {
+	width := make([]int, len(header))
+	for _, row := range append([][]string{header}, rows...) {
+		for j, cell := range row {
+			if len(cell) > width[j] {
+				width[j] = len(cell)
+			}
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteByte('|')
+	for j, cell := range header {
+		fmt.Fprintf(&sb, " %-*s |", width[j], cell)
+	}
+	fmt.Fprintf(&sb, "\n|:%s|", strings.Repeat("-", width[0]+1))
+	for j := 1; j < len(header); j++ {
+		fmt.Fprintf(&sb, "%s:|", strings.Repeat("-", width[j]+1))
+	}
+	sb.WriteByte('\n')
+	for _, row := range rows {
+		fmt.Fprintf(&sb, "| %-*s |", width[0], row[0])
+		for j := 1; j < len(row); j++ {
+			fmt.Fprintf(&sb, " %*s |", width[j], row[j])
+		}
+		sb.WriteByte('\n')
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func init() {
+	flag.Var(optionalBool{&methodNotAllowed}, "405", "set the native option of the routers which have one for answering with 405 Method Not Allowed, e.g. -405=false")
+}
+
+// optionalBool is a boolean flag which is nil unless set.
+type optionalBool struct {
+	p **bool
+}
+
+func (o optionalBool) String() string {
+	if o.p == nil || *o.p == nil {
+		return ""
+	}
+	return strconv.FormatBool(**o.p)
+}
+
+func (o optionalBool) Set(s string) error {
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	*o.p = &v
+	return nil
+}
+
+func (o optionalBool) IsBoolFlag() bool { return true }
+
+// generateWrongMethods generates up to classRequests requests for the paths
+// of the API with a method no route matching the path has.
+func (a *api) generateWrongMethods() {
+	rnd := rand.New(rand.NewSource(*paramSeed))
+	seen := make(map[string]bool)
+	a.wrongMethods = nil
+	a.sampleRoutes(rnd, func(i int) bool {
+		path := a.paths[i]
+		if seen[path] {
+			return false
+		}
+		seen[path] = true
+
+		allowed := a.allowed(path)
+		var methods []string
+		for _, method := range routeMethods {
+			if !contains(allowed, method) {
+				methods = append(methods, method)
+			}
+		}
+		if len(methods) == 0 {
+			return false
+		}
+		a.wrongMethods = append(a.wrongMethods, route{methods[rnd.Intn(len(methods))], path})
+		return true
+	})
+}
+
+// allowed returns the methods of all routes of the API matching the path.
+func (a *api) allowed(path string) []string {
+	var methods []string
+	for _, route := range a.routes {
+		if routeMatches(route.path, path) && !contains(methods, route.method) {
+			methods = append(methods, route.method)
+		}
+	}
+	return methods
+}
+
+// allowCorrect reports whether the Allow header lists all allowed methods.
+// HEAD and OPTIONS may be listed additionally, since many routers answer
+// them automatically.
+func allowCorrect(header string, allowed []string) bool {
+	listed := make(map[string]bool)
+	for _, method := range strings.Split(header, ",") {
+		listed[strings.TrimSpace(method)] = true
+	}
+	for _, method := range allowed {
+		if !listed[method] {
+			return false
+		}
+		delete(listed, method)
+	}
+	delete(listed, "HEAD")
+	delete(listed, "OPTIONS")
+	return len(listed) == 0
+}
+
+// countCells formats counts by key as "key (count)", ordered by key, or as
+// just the key if there is only one.
+func countCells(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if len(keys) == 1 {
+		return keys[0]
+	}
+	for i, key := range keys {
+		keys[i] = fmt.Sprintf("%s (%d)", key, counts[key])
+	}
+	return strings.Join(keys, ", ")
+}
+
+// classCounts returns a map of counts by outcome for each of n classes.
+func classCounts(n int) []map[string]int {
+	counts := make([]map[string]int, n)
+	for c := range counts {
+		counts[c] = make(map[string]int)
+	}
+	return counts
+}
+
+// classRow returns the table row of the router with a cell per class, which
+// is - for classes without any counts.
+func classRow(router string, counts []map[string]int) []string {
+	row := []string{router}
+	for _, c := range counts {
+		cell := "-"
+		if len(c) > 0 {
+			cell = countCells(c)
+		}
+		row = append(row, cell)
+	}
+	return row
+}
+
+// TestMethodNotAllowed sends requests for known paths with a method no route
+// has and prints which status the routers answer with and whether the Allow
+// header of 405 responses is correct, with -v. Routers answering with 2xx
+// ignore the method.
+func TestMethodNotAllowed(t *testing.T) {
+	var rows [][]string
+	for _, router := range routers {
+		if router.skipTest || router.skipUnmatched {
+			continue
+		}
+
+		statuses := make(map[string]int)
+		allows := make(map[string]int)
+		for _, api := range apis {
+			if !api.supportedBy(router) {
+				continue
+			}
+			h := router.build(api.routes, testHandler)
+
+			for _, req := range api.wrongMethods {
+				r, _ := http.NewRequest(req.method, req.path, nil)
+				w := httptest.NewRecorder()
+				h.ServeHTTP(w, r)
+
+				statuses[strconv.Itoa(w.Code)]++
+				if w.Code != http.StatusMethodNotAllowed {
+					continue
+				}
+				switch allow := w.Header().Get("Allow"); {
+				case allow == "":
+					allows["missing"]++
+				case allowCorrect(allow, api.allowed(req.path)):
+					allows["correct"]++
+				default:
+					allows["wrong"]++
+				}
+			}
+		}
+		if len(statuses) == 0 {
+			continue
+		}
+
+		option := "-"
+		if router.methodNotAllowedOption {
+			option = "default"
+			if methodNotAllowed != nil {
+				option = strconv.FormatBool(*methodNotAllowed)
+			}
+		}
+		allow := "-"
+		if len(allows) > 0 {
+			allow = countCells(allows)
+		}
+		rows = append(rows, []string{router.name, option, countCells(statuses), allow})
+	}
+
+	if testing.Verbose() {
+		header := []string{"Router", "Option", "Status", "Allow"}
+		if err := writeMarkdownTable(os.Stdout, header, rows); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestAllowCorrect(t *testing.T) {
+	allowed := []string{"GET", "DELETE"}
+	for header, correct := range map[string]bool{
+		"GET, DELETE":                true,
+		"DELETE,GET":                 true,
+		"GET, DELETE, HEAD, OPTIONS": true,
+		"GET":                        false,
+		"GET, DELETE, PUT":           false,
+	} {
+		if allowCorrect(header, allowed) != correct {
+			t.Errorf("allowCorrect(%q, %v) = %v", header, allowed, !correct)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// missClasses generate near-miss paths, which are derived from the request
+// path of a route but are not matched by any route. A class may return an
+// empty string if it can not derive a path from a route.
+var missClasses = []struct {
+	name string
+	miss func(route, path string, rnd *rand.Rand) string
+}{
+	// A static segment differs in its last character, so that the path
+	// shares a long prefix with the route.
+	{"Divergent", func(route, path string, rnd *rand.Rand) string {
+		var static []int
+		for i, s := range strings.Split(route, "/") {
+			if s != "" && s[0] != ':' && s[0] != '*' {
+				static = append(static, i)
+			}
+		}
+		if len(static) == 0 {
+			return ""
+		}
+		segments := strings.Split(path, "/")
+		i := static[rnd.Intn(len(static))]
+		s := segments[i]
+		c := byte('x')
+		if s[len(s)-1] == c {
+			c = 'y'
+		}
+		segments[i] = s[:len(s)-1] + string(c)
+		return strings.Join(segments, "/")
+	}},
+	// An additional segment is appended.
+	{"ExtraSegment", func(_, path string, rnd *rand.Rand) string {
+		return strings.TrimSuffix(path, "/") + "/" + wordKind.generate(rnd)
+	}},
+	// A segment is missing.
+	{"MissingSegment", func(_, path string, rnd *rand.Rand) string {
+		segments := strings.Split(path, "/")
+		if len(segments) < 3 {
+			return ""
+		}
+		i := 1 + rnd.Intn(len(segments)-1)
+		return strings.Join(append(segments[:i:i], segments[i+1:]...), "/")
+	}},
+	// The first segment is unknown.
+	{"UnknownTop", func(_, path string, rnd *rand.Rand) string {
+		segments := strings.Split(path, "/")
+		segments[1] = wordKind.generate(rnd)
+		return strings.Join(segments, "/")
+	}},
+}
+
+// routeMatches reports whether the request path matches the route, with
+// parameters matching any non-empty segment satisfying their constraint and
+// catch-all parameters matching the rest of the path.
+func routeMatches(route, path string) bool {
+	patterns, segments := strings.Split(route, "/"), strings.Split(path, "/")
+	for i, p := range patterns {
+		if i >= len(segments) {
+			return false
+		}
+		switch {
+		case strings.HasPrefix(p, "*"):
+			return true
+		case strings.HasPrefix(p, ":"):
+			if segments[i] == "" {
+				return false
+			}
+			if m := paramPattern.FindStringSubmatch(p); m[2] != "" && !constraintRe(m[2]).MatchString(segments[i]) {
+				return false
+			}
+		case p != segments[i]:
+			return false
+		}
+	}
+	return len(patterns) == len(segments)
+}
+
+// generateMisses generates up to classRequests near-miss paths for each miss
+// class, which no route of the API matches with any method.
+func (a *api) generateMisses() {
+	rnd := rand.New(rand.NewSource(*paramSeed))
+	a.misses = make([][]string, len(missClasses))
+	for c, class := range missClasses {
+		seen := make(map[string]bool)
+		a.sampleRoutes(rnd, func(i int) bool {
+			path := class.miss(a.routes[i].path, a.paths[i], rnd)
+			if path == "" || seen[path] || a.matches(path) {
+				return false
+			}
+			seen[path] = true
+			a.misses[c] = append(a.misses[c], path)
+			return true
+		})
+	}
+}
+
+// matches reports whether any route of the API matches the path.
+func (a *api) matches(path string) bool {
+	for _, route := range a.routes {
+		if routeMatches(route.path, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// getRequests returns GET requests for the paths.
+func getRequests(paths []string) []route {
+	requests := make([]route, len(paths))
+	for i, path := range paths {
+		requests[i] = route{"GET", path}
+	}
+	return requests
+}
+
+// TestNotFound checks that the routers answer near misses with 404. Serving
+// a near miss is an error. Other answers, e.g. redirects to the path with a
+// trailing slash, are deviations which are only logged, once per router and
+// status code.
+func TestNotFound(t *testing.T) {
+	for _, router := range routers {
+		if router.skipTest || router.skipUnmatched {
+			continue
+		}
+
+		// number of deviations and the first request, by status code
+		deviations := make(map[int]int)
+		examples := make(map[int]string)
+
+		for _, api := range apis {
+			if !api.supportedBy(router) {
+				continue
+			}
+			h := router.build(api.routes, testHandler)
+
+			for c, paths := range api.misses {
+				for _, path := range paths {
+					r, _ := http.NewRequest("GET", path, nil)
+					w := httptest.NewRecorder()
+					h.ServeHTTP(w, r)
+					switch {
+					case w.Code == http.StatusNotFound:
+					case w.Code < 300:
+						t.Errorf("%s in API %s: %s request GET %s: got %d, expected 404",
+							router.name, api.name, missClasses[c].name, path, w.Code)
+					default:
+						if deviations[w.Code] == 0 {
+							examples[w.Code] = fmt.Sprintf("%s request GET %s in API %s",
+								missClasses[c].name, path, api.name)
+						}
+						deviations[w.Code]++
+					}
+				}
+			}
+		}
+
+		codes := make([]int, 0, len(deviations))
+		for code := range deviations {
+			codes = append(codes, code)
+		}
+		sort.Ints(codes)
+		for _, code := range codes {
+			t.Logf("%s: answers %d instead of 404 for %d near misses, e.g. the %s",
+				router.name, code, deviations[code], examples[code])
+		}
+	}
+}
+
+func TestRouteMatches(t *testing.T) {
+	for _, test := range []struct {
+		route, path string
+		match       bool
+	}{
+		{"/users/:user", "/users/gordon", true},
+		{"/users/:user", "/users/", false},
+		{"/users/:user", "/users/gordon/repos", false},
+		{"/users/:user", "/users", false},
+		{"/user", "/users", false},
+		{"/src/*filepath", "/src/a/b.go", true},
+		{"/users/:id([0-9]+)", "/users/42", true},
+		{"/users/:id([0-9]+)", "/users/gordon", false},
+		{"/users/:id([0-9]+)", "/users/42a", false},
+		{"/", "/", true},
+		{"/", "/a", false},
+	} {
+		if got := routeMatches(test.route, test.path); got != test.match {
+			t.Errorf("routeMatches(%q, %q) = %v, expected %v", test.route, test.path, got, test.match)
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+var openAPIFiles fileList
+
+func init() {
+	flag.Var(&openAPIFiles, "openapi", "add the OpenAPI 3 or Swagger 2 document in `file` (JSON or YAML) as API; may be repeated")
+}
+
+// fileList is a flag which may be given multiple times.
+type fileList []string
+
+func (l *fileList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *fileList) Set(file string) error {
+	*l = append(*l, file)
+	return nil
+}
+
+// openAPIDoc is the part of an OpenAPI 3 or Swagger 2 document describing
+// the routes.
+type openAPIDoc struct {
+	OpenAPI string `json:"openapi" yaml:"openapi"`
+	Servers []struct {
+		URL       string `json:"url" yaml:"url"`
+		Variables map[string]struct {
+			Default string `json:"default" yaml:"default"`
+		} `json:"variables" yaml:"variables"`
+	} `json:"servers" yaml:"servers"`
+
+	Swagger  string `json:"swagger" yaml:"swagger"`
+	BasePath string `json:"basePath" yaml:"basePath"`
+
+	Paths map[string]map[string]interface{} `json:"paths" yaml:"paths"`
+}
+
+// openAPIMethods are the operations of a path item, in the order they are
+// imported.
+var openAPIMethods = []struct {
+	field, method string
+}{
+	{"get", "GET"},
+	{"post", "POST"},
+	{"put", "PUT"},
+	{"patch", "PATCH"},
+	{"delete", "DELETE"},
+	{"head", "HEAD"},
+	{"options", "OPTIONS"},
+	{"trace", "TRACE"},
+}
+
+var paramSyntax = regexp.MustCompile(":[^/]*")
+
+// serverVariable is a variable in the URL of an OpenAPI 3 server, which is
+// replaced by its default value.
+var serverVariable = regexp.MustCompile(`\{[^{}]*\}`)
+
+// importOpenAPI converts an OpenAPI 3 or Swagger 2 document to routes. The
+// document is parsed as YAML, unless isJSON is set. Operations which can not
+// be expressed as route are returned as skipped, including the reason.
+//
+// Many routers require parameters at the same position to have the same
+// name, e.g. /pets/:id and /pets/:petId/photos conflict. Such parameters are
+// renamed to the name of the first one. Operations whose path would then
+// have two parameters with the same name are skipped.
+func importOpenAPI(data []byte, isJSON bool) (routes []route, skipped []string, err error) {
+	var doc openAPIDoc
+	if isJSON {
+		err = json.Unmarshal(data, &doc)
+	} else {
+		err = yaml.Unmarshal(data, &doc)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var base string
+	switch {
+	case strings.HasPrefix(doc.OpenAPI, "3."):
+		if len(doc.Servers) > 0 {
+			server := doc.Servers[0]
+			rawURL := serverVariable.ReplaceAllStringFunc(server.URL, func(v string) string {
+				name := v[1 : len(v)-1]
+				if _, ok := server.Variables[name]; !ok && err == nil {
+					err = fmt.Errorf("server url: variable %s is not defined", name)
+				}
+				return server.Variables[name].Default
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+			u, err := url.Parse(rawURL)
+			if err != nil {
+				return nil, nil, fmt.Errorf("server url: %v", err)
+			}
+			base = u.Path
+		}
+	case strings.HasPrefix(doc.Swagger, "2."):
+		base = doc.BasePath
+	default:
+		return nil, nil, errors.New("neither an OpenAPI 3 nor a Swagger 2 document")
+	}
+	base = strings.TrimSuffix(base, "/")
+
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	// routes matching the same requests, by method and path with all
+	// parameter names removed
+	seen := make(map[string]string)
+	// parameter names, by the path before them
+	params := make(map[string]string)
+
+	for _, path := range paths {
+		item := doc.Paths[path]
+		if _, ok := item["$ref"]; ok {
+			skipped = append(skipped, path+": references to path items are not supported")
+			continue
+		}
+
+		p, err := openAPIPath(base + path)
+		if err == nil {
+			p, err = unifyParams(p, params)
+		}
+		for _, m := range openAPIMethods {
+			if _, ok := item[m.field]; !ok {
+				continue
+			}
+			switch {
+			case err != nil:
+				skipped = append(skipped, fmt.Sprintf("%s %s: %v", m.method, path, err))
+			case !contains(routeMethods, m.method):
+				skipped = append(skipped, fmt.Sprintf("%s %s: method not supported", m.method, path))
+			default:
+				key := m.method + " " + paramSyntax.ReplaceAllString(p, ":")
+				if other, ok := seen[key]; ok {
+					skipped = append(skipped, fmt.Sprintf("%s %s: matches the same requests as %s", m.method, path, other))
+					continue
+				}
+				seen[key] = path
+				routes = append(routes, route{m.method, p})
+			}
+		}
+	}
+	return routes, skipped, nil
+}
+
+// openAPIPath converts a path template to the :param syntax. Parameters have
+// to span a whole segment.
+func openAPIPath(path string) (string, error) {
+	if !strings.HasPrefix(path, "/") {
+		return "", errors.New("path does not start with /")
+	}
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if !strings.ContainsAny(s, "{}") {
+			if strings.ContainsAny(s, ":*") {
+				return "", fmt.Errorf("segment %q contains parameter syntax", s)
+			}
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(s, "{"), "}")
+		if len(name)+2 != len(s) || name == "" || strings.ContainsAny(name, "{}") {
+			return "", fmt.Errorf("parameter in segment %q is not the whole segment", s)
+		}
+		segments[i] = ":" + name
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+// unifyParams renames the parameters of path which follow the same path as
+// an earlier parameter, which is recorded in names. It fails if two
+// parameters of the renamed path have the same name, in which case names is
+// left unchanged.
+func unifyParams(path string, names map[string]string) (string, error) {
+	segments := strings.Split(path, "/")
+	added := make(map[string]string)
+	used := make(map[string]bool)
+	for i, s := range segments {
+		if !strings.HasPrefix(s, ":") {
+			continue
+		}
+		prefix := paramSyntax.ReplaceAllString(strings.Join(segments[:i], "/"), ":")
+		if name, ok := names[prefix]; ok {
+			segments[i] = name
+		} else {
+			added[prefix] = s
+		}
+		if used[segments[i]] {
+			return "", fmt.Errorf("parameter %s occurs twice after renaming parameters to match other paths", segments[i])
+		}
+		used[segments[i]] = true
+	}
+	for prefix, name := range added {
+		names[prefix] = name
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+// importOpenAPIFiles adds the documents given by the openapi flag as APIs,
+// named after the files.
+func importOpenAPIFiles() error {
+	for _, file := range openAPIFiles {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		ext := filepath.Ext(file)
+		routes, skipped, err := importOpenAPI(data, strings.EqualFold(ext, ".json"))
+		if err != nil {
+			return fmt.Errorf("%s: %v", file, err)
+		}
+		for _, s := range skipped {
+			fmt.Fprintf(os.Stderr, "%s: skipping %s\n", file, s)
+		}
+		name := strings.TrimSuffix(filepath.Base(file), ext)
+		if err = addAPI(&api{name: name, routes: routes}); err != nil {
+			return fmt.Errorf("%s: %v", file, err)
+		}
+	}
+	return nil
+}
+
+func TestImportOpenAPI(t *testing.T) {
+	swagger := `
+swagger: "2.0"
+basePath: /v1/
+paths:
+  /pets:
+    get: {}
+    post: {}
+    head: {}
+  /pets/{petId}:
+    get: {}
+    delete: {}
+  /pets/{id}:
+    get: {}
+  /pets/{petId}/photo.{format}:
+    get: {}
+  /pets/{petId}/photos/{id}:
+    get: {}
+  /stores:
+    $ref: "#/x-stores"
+`
+	routes, skipped, err := importOpenAPI([]byte(swagger), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantRoutes := []route{
+		{"GET", "/v1/pets"},
+		{"POST", "/v1/pets"},
+		{"GET", "/v1/pets/:id"},
+		{"DELETE", "/v1/pets/:id"},
+	}
+	if !reflect.DeepEqual(routes, wantRoutes) {
+		t.Errorf("routes: got %v, want %v", routes, wantRoutes)
+	}
+	if len(skipped) != 5 {
+		t.Errorf("expected 5 skipped operations, got %q", skipped)
+	}
+
+	openAPI := `{
+		"openapi": "3.0.0",
+		"servers": [{
+			"url": "https://{host}.example.com/{version}",
+			"variables": {"host": {"default": "api"}, "version": {"default": "v1"}}
+		}],
+		"paths": {"/users/{user}": {"patch": {}, "parameters": []}}
+	}`
+	routes, skipped, err = importOpenAPI([]byte(openAPI), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantRoutes = []route{{"PATCH", "/v1/users/:user"}}
+	if !reflect.DeepEqual(routes, wantRoutes) || len(skipped) != 0 {
+		t.Errorf("got %v and skipped %q, want %v", routes, skipped, wantRoutes)
+	}
+
+	undefined := `{"openapi": "3.0.0", "servers": [{"url": "/{version}"}], "paths": {}}`
+	if _, _, err = importOpenAPI([]byte(undefined), true); err == nil {
+		t.Error("expected an error for an undefined server variable")
+	}
+
+	if _, _, err = importOpenAPI([]byte(`{"paths": {}}`), true); err == nil {
+		t.Error("expected an error for a document without version")
+	}
+}
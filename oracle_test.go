@@ -0,0 +1,182 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// segmentKind is the kind of a segment of a route. The kinds are ordered by
+// precedence, a static segment is the most specific one.
+type segmentKind int
+
+const (
+	staticSegment segmentKind = iota
+	constrainedSegment
+	paramSegment
+	catchAllSegment
+)
+
+func kindOfSegment(s string) segmentKind {
+	switch {
+	case strings.HasPrefix(s, "*"):
+		return catchAllSegment
+	case strings.HasPrefix(s, ":") && strings.HasSuffix(s, ")"):
+		return constrainedSegment
+	case strings.HasPrefix(s, ":"):
+		return paramSegment
+	default:
+		return staticSegment
+	}
+}
+
+// moreSpecific reports whether the route a takes precedence over the route b
+// for a path both match. The first segment in which the kinds of their
+// segments differ decides. If there is none, neither takes precedence.
+func moreSpecific(a, b string) bool {
+	sa, sb := strings.Split(a, "/"), strings.Split(b, "/")
+	for i := 0; i < len(sa) && i < len(sb); i++ {
+		if ka, kb := kindOfSegment(sa[i]), kindOfSegment(sb[i]); ka != kb {
+			return ka < kb
+		}
+	}
+	return false
+}
+
+// oracleMatch is the reference matcher the routers are compared with. It
+// returns the route with the method which matches the path, as defined by
+// routeMatches, and reports whether there is one. The path is matched as it
+// is, i.e. it is neither cleaned nor are trailing slashes ignored.
+//
+// If multiple routes match, the most specific one wins: a static segment
+// beats a constrained parameter, which beats an unconstrained parameter,
+// which beats a catch-all parameter, and the first segment in which the
+// routes differ decides. For example /users/new beats /users/:user and
+// /src/:file beats /src/*filepath. If the routes do not differ in the kinds
+// of their segments, e.g. /:id([0-9]+) and /:sha([0-9a-f]+), the route
+// listed first wins.
+func oracleMatch(routes []route, method, path string) (route, bool) {
+	var match route
+	found := false
+	for _, r := range routes {
+		if r.method != method || !routeMatches(r.path, path) {
+			continue
+		}
+		if !found || moreSpecific(r.path, match.path) {
+			match, found = r, true
+		}
+	}
+	return match, found
+}
+
+// oracleRouter serves requests as defined by oracleMatch. It is not
+// registered with the routers, but may be used as their reference, e.g. by
+// TestDifferential.
+var oracleRouter = &routerAdapter{
+	name:       "Oracle",
+	load:       loadOracle,
+	catchAll:   true,
+	constraint: anyConstraint,
+}
+
+// loadOracle returns a handler which serves requests of a route with the test
+// handler. Requests for a path which only routes with other methods match are
+// answered with 405 Method Not Allowed and the methods in the Allow header,
+// all others with 404 Not Found.
+func loadOracle(routes []route, _ handlerMode) http.Handler {
+	a := &api{routes: routes}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		match, ok := oracleMatch(routes, r.Method, r.URL.Path)
+		if !ok {
+			if allowed := a.allowed(r.URL.Path); len(allowed) > 0 {
+				w.Header().Set("Allow", strings.Join(allowed, ", "))
+				http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+				return
+			}
+			http.NotFound(w, r)
+			return
+		}
+		params := pathParams(match.path, r.URL.Path)
+		writeTest(w, r, match, r.RequestURI, func(name string) string {
+			return params[name]
+		})
+	})
+}
+
+func TestOracleMatch(t *testing.T) {
+	routes := []route{
+		{"GET", "/users/new"},
+		{"GET", "/users/:user"},
+		{"GET", "/users/:id([0-9]+)"},
+		{"GET", "/users/:user/repos"},
+		{"GET", "/users/*path"},
+		{"POST", "/users/:user"},
+		{"GET", "/src/*filepath"},
+		{"GET", "/src/:file"},
+		{"GET", "/:a([0-9]+)/x"},
+		{"GET", "/:b([0-9a-f]+)/x"},
+		{"GET", "/"},
+	}
+	for _, test := range []struct {
+		method, path string
+		route        string
+	}{
+		{"GET", "/users/new", "/users/new"},
+		{"GET", "/users/gordon", "/users/:user"},
+		{"GET", "/users/42", "/users/:id([0-9]+)"},
+		{"GET", "/users/gordon/repos", "/users/:user/repos"},
+		{"GET", "/users/gordon/gists", "/users/*path"},
+		{"GET", "/users/", "/users/*path"},
+		{"POST", "/users/gordon", "/users/:user"},
+		{"POST", "/users/new", "/users/:user"},
+		{"GET", "/src/main.go", "/src/:file"},
+		{"GET", "/src/cmd/main.go", "/src/*filepath"},
+		{"GET", "/42/x", "/:a([0-9]+)/x"},
+		{"GET", "/4f/x", "/:b([0-9a-f]+)/x"},
+		{"GET", "/", "/"},
+		{"PUT", "/users/gordon", ""},
+		{"GET", "/src", ""},
+		{"GET", "/users/gordon/", "/users/*path"},
+		{"GET", "/4g/x", ""},
+	} {
+		match, ok := oracleMatch(routes, test.method, test.path)
+		if test.route == "" {
+			if ok {
+				t.Errorf("%s %s: matched %s, expected no match", test.method, test.path, match.path)
+			}
+			continue
+		}
+		if !ok || match.path != test.route {
+			t.Errorf("%s %s: matched %q, expected %s", test.method, test.path, match.path, test.route)
+		}
+	}
+
+	h := loadOracle(routes, testHandler)
+	for _, test := range []struct {
+		method, path string
+		params       string
+		code         int
+		allow        string
+		body         string
+	}{
+		{"GET", "/users/gordon", "user", 200, "", "/users/gordon\nuser=gordon"},
+		{"GET", "/src/cmd/main.go", "filepath", 200, "", "/src/cmd/main.go\nfilepath=cmd/main.go"},
+		{"DELETE", "/users/gordon", "", 405, "GET, POST", ""},
+		{"GET", "/repos", "", 404, "", ""},
+	} {
+		r := httptest.NewRequest(test.method, test.path, nil)
+		r.Header.Set(paramsHeader, test.params)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		got := []interface{}{w.Code, w.Header().Get("Allow")}
+		want := []interface{}{test.code, test.allow}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s %s: answered %v, expected %v", test.method, test.path, got, want)
+		}
+		if test.code == 200 && w.Body.String() != test.body {
+			t.Errorf("%s %s: wrote %q, expected %q", test.method, test.path, w.Body.String(), test.body)
+		}
+	}
+}
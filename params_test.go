@@ -0,0 +1,235 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var paramSeed = flag.Int64("params.seed", 1, "`seed` of the parameter values in requests")
+
+func init() {
+	flag.Var(paramLengths{}, "params.len", "comma-separated length ranges of parameter values as `kind=min:max`, e.g. id=1:9,user=3:15")
+}
+
+// paramKind is a type of parameter values, which are between min and max
+// characters long.
+type paramKind struct {
+	name     string
+	min, max int
+	value    func(rnd *rand.Rand, n int) string
+}
+
+const (
+	lowerLetters = "abcdefghijklmnopqrstuvwxyz"
+	digits       = "0123456789"
+	hexDigits    = "0123456789abcdef"
+)
+
+var fileExtensions = []string{"txt", "json", "png", "go", "tar.gz"}
+
+var (
+	idKind = &paramKind{"id", 1, 9, func(rnd *rand.Rand, n int) string {
+		return string(digits[1+rnd.Intn(9)]) + randomString(rnd, digits, n-1)
+	}}
+	shaKind = &paramKind{"sha", 40, 40, func(rnd *rand.Rand, n int) string {
+		return randomString(rnd, hexDigits, n)
+	}}
+	uuidKind = &paramKind{"uuid", 36, 36, func(rnd *rand.Rand, _ int) string {
+		s := randomString(rnd, hexDigits, 32)
+		return s[:8] + "-" + s[8:12] + "-4" + s[13:16] + "-a" + s[17:20] + "-" + s[20:]
+	}}
+	userKind = &paramKind{"user", 3, 15, func(rnd *rand.Rand, n int) string {
+		return string(lowerLetters[rnd.Intn(26)]) + randomString(rnd, lowerLetters+digits+"-", n-2) +
+			string(lowerLetters[rnd.Intn(26)])
+	}}
+	emailKind = &paramKind{"email", 3, 15, func(rnd *rand.Rand, n int) string {
+		return userKind.value(rnd, n) + "@example.com"
+	}}
+	fileKind = &paramKind{"file", 3, 12, func(rnd *rand.Rand, n int) string {
+		return randomString(rnd, lowerLetters+digits+"_", n) + "." +
+			fileExtensions[rnd.Intn(len(fileExtensions))]
+	}}
+	wordKind = &paramKind{"word", 3, 12, func(rnd *rand.Rand, n int) string {
+		return randomString(rnd, lowerLetters, n)
+	}}
+)
+
+var paramKinds = []*paramKind{idKind, shaKind, uuidKind, userKind, emailKind, fileKind, wordKind}
+
+func randomString(rnd *rand.Rand, chars string, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = chars[rnd.Intn(len(chars))]
+	}
+	return string(b)
+}
+
+// kindOf returns the kind of values of the parameter, which is guessed from
+// its name.
+func kindOf(param string) *paramKind {
+	name := strings.ToLower(strings.Replace(param, "_", "", -1))
+	switch {
+	case strings.Contains(name, "uuid") || strings.Contains(name, "guid"):
+		return uuidKind
+	case name == "sha" || name == "commit":
+		return shaKind
+	case name == "id" || name == "number" || strings.HasSuffix(name, "id"):
+		return idKind
+	case name == "email":
+		return emailKind
+	case strings.Contains(name, "user") || name == "owner" || name == "org" || name == "login":
+		return userKind
+	case strings.Contains(name, "file"):
+		return fileKind
+	default:
+		return wordKind
+	}
+}
+
+func (k *paramKind) generate(rnd *rand.Rand) string {
+	return k.value(rnd, k.min+rnd.Intn(k.max-k.min+1))
+}
+
+var paramRe = regexp.MustCompile(`[:*][^/]*`)
+
+// concretePath returns a request path for the route, with realistic values
+// for its parameters. Catch-all parameters get a file path of up to 3
+// segments. The kind of constrained parameters has to match the constraint,
+// e.g. :id([0-9]+).
+func concretePath(path string, rnd *rand.Rand) string {
+	return paramRe.ReplaceAllStringFunc(path, func(param string) string {
+		if param[0] == '*' {
+			return filePath(rnd, rnd.Intn(3))
+		}
+		return kindOf(paramName(param)).generate(rnd)
+	})
+}
+
+// paramName returns the name of the parameter without its : prefix and
+// constraint.
+func paramName(param string) string {
+	if i := strings.IndexByte(param, '('); i >= 0 {
+		param = param[:i]
+	}
+	return param[1:]
+}
+
+// filePath returns a relative path of a file in dirs nested directories.
+func filePath(rnd *rand.Rand, dirs int) string {
+	segments := make([]string, dirs, dirs+1)
+	for i := range segments {
+		segments[i] = wordKind.generate(rnd)
+	}
+	return strings.Join(append(segments, fileKind.generate(rnd)), "/")
+}
+
+// concretePaths returns a request path for every route. The values only
+// depend on the routes and the params.seed flag.
+func concretePaths(routes []route) []string {
+	rnd := rand.New(rand.NewSource(*paramSeed))
+	paths := make([]string, len(routes))
+	for i, route := range routes {
+		paths[i] = concretePath(route.path, rnd)
+	}
+	return paths
+}
+
+// pathParams returns the values of the parameters of the route in the
+// request path, by name.
+func pathParams(route, path string) map[string]string {
+	params := make(map[string]string)
+	segments := strings.Split(path, "/")
+	for i, s := range strings.Split(route, "/") {
+		if i >= len(segments) {
+			break
+		}
+		switch {
+		case strings.HasPrefix(s, ":"):
+			params[paramName(s)] = segments[i]
+		case strings.HasPrefix(s, "*"):
+			params[s[1:]] = strings.Join(segments[i:], "/")
+		}
+	}
+	return params
+}
+
+// paramLengths is the value of the params.len flag, which sets the length
+// ranges of paramKinds.
+type paramLengths struct{}
+
+func (paramLengths) String() string {
+	var ranges []string
+	for _, k := range paramKinds {
+		ranges = append(ranges, fmt.Sprintf("%s=%d:%d", k.name, k.min, k.max))
+	}
+	return strings.Join(ranges, ",")
+}
+
+func (paramLengths) Set(s string) error {
+	for _, spec := range strings.Split(s, ",") {
+		i, j := strings.IndexByte(spec, '='), strings.IndexByte(spec, ':')
+		if i < 0 || j < i {
+			return fmt.Errorf("expected kind=min:max, got %s", spec)
+		}
+		var kind *paramKind
+		for _, k := range paramKinds {
+			if k.name == spec[:i] {
+				kind = k
+			}
+		}
+		min, err1 := strconv.Atoi(spec[i+1 : j])
+		max, err2 := strconv.Atoi(spec[j+1:])
+		switch {
+		case kind == nil:
+			return fmt.Errorf("unknown kind %s", spec[:i])
+		case kind == uuidKind:
+			return fmt.Errorf("the length of %s values is fixed", kind.name)
+		case err1 != nil || err2 != nil || min < 2 || max < min:
+			return fmt.Errorf("invalid length range in %s", spec)
+		}
+		kind.min, kind.max = min, max
+	}
+	return nil
+}
+
+func TestConcretePath(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for path, re := range map[string]string{
+		"/repos/:owner/:repo/commits/:sha": `^/repos/[a-z][a-z0-9-]{1,13}[a-z]/[a-z]{3,12}/commits/[0-9a-f]{40}$`,
+		"/gists/:id":                       `^/gists/[1-9][0-9]{0,8}$`,
+		"/1/files/:fileName":               `^/1/files/[a-z0-9_]{3,12}\.[a-z.]+$`,
+		"/static/*filepath":                `^/static/([a-z]{3,12}/){0,2}[a-z0-9_]{3,12}\.[a-z.]+$`,
+		"/devices/:uuid":                   `^/devices/[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-a[0-9a-f]{3}-[0-9a-f]{12}$`,
+	} {
+		for i := 0; i < 100; i++ {
+			if p := concretePath(path, rnd); !regexp.MustCompile(re).MatchString(p) {
+				t.Fatalf("%s: %s does not match %s", path, p, re)
+			}
+		}
+	}
+
+	params := pathParams("/repos/:owner/:repo/contents/*path", "/repos/julienschmidt/httprouter/contents/a/b.go")
+	want := map[string]string{"owner": "julienschmidt", "repo": "httprouter", "path": "a/b.go"}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("got params %v, want %v", params, want)
+	}
+
+	if err := (paramLengths{}).Set("sha=7:7"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { shaKind.min, shaKind.max = 40, 40 }()
+	if p := concretePath("/:sha", rnd); len(p) != 8 {
+		t.Errorf("expected a sha of 7 characters, got %s", p)
+	}
+	for _, s := range []string{"sha", "sha=1:7", "sha=7:1", "uuid=1:2", "foo=1:2"} {
+		if err := (paramLengths{}).Set(s); err == nil {
+			t.Errorf("expected an error for %s", s)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// variantClasses derive a variant of a path, which is not clean in the sense
+// of path.Clean but cleans to the same path.
+var variantClasses = []struct {
+	name    string
+	variant func(path string, rnd *rand.Rand) string
+}{
+	// /user/repos/
+	{"TrailingSlash", func(path string, _ *rand.Rand) string {
+		if strings.HasSuffix(path, "/") {
+			return ""
+		}
+		return path + "/"
+	}},
+	// /user//repos
+	{"DoubleSlash", func(path string, rnd *rand.Rand) string {
+		i := randomSlash(path, rnd)
+		return path[:i] + "/" + path[i:]
+	}},
+	// /user/./repos
+	{"DotSegment", func(path string, rnd *rand.Rand) string {
+		i := randomSlash(path, rnd)
+		return path[:i] + "/." + path[i:]
+	}},
+	// /user/abc/../repos
+	{"DotDotSegment", func(path string, rnd *rand.Rand) string {
+		i := randomSlash(path, rnd)
+		return path[:i] + "/" + wordKind.generate(rnd) + "/.." + path[i:]
+	}},
+}
+
+// randomSlash returns the index of a random slash of the path, except a
+// trailing one.
+func randomSlash(path string, rnd *rand.Rand) int {
+	var slashes []int
+	for i := 0; i < len(path)-1; i++ {
+		if path[i] == '/' {
+			slashes = append(slashes, i)
+		}
+	}
+	return slashes[rnd.Intn(len(slashes))]
+}
+
+// pathVariant is a request for a variant of the clean path of a route.
+type pathVariant struct {
+	request route
+	clean   string
+
+	// route is the route serving the clean path.
+	route route
+}
+
+// generateVariants generates up to classRequests variants for each variant
+// class from the paths of the API.
+func (a *api) generateVariants() {
+	rnd := rand.New(rand.NewSource(*paramSeed))
+	a.variants = make([][]pathVariant, len(variantClasses))
+	for c, class := range variantClasses {
+		a.sampleRoutes(rnd, func(i int) bool {
+			if a.paths[i] == "/" {
+				return false
+			}
+			path := class.variant(a.paths[i], rnd)
+			if path == "" {
+				return false
+			}
+			match, _ := oracleMatch(a.routes, a.routes[i].method, a.paths[i])
+			a.variants[c] = append(a.variants[c], pathVariant{
+				request: route{a.routes[i].method, path},
+				clean:   a.paths[i],
+				route:   match,
+			})
+			return true
+		})
+	}
+}
+
+// variantRequests returns the requests of the variants.
+func variantRequests(variants []pathVariant) []route {
+	requests := make([]route, len(variants))
+	for i, v := range variants {
+		requests[i] = v.request
+	}
+	return requests
+}
+
+// variantOutcome classifies the answer to a variant: the status code, which
+// for redirects is followed by whether they redirect to the clean path, or
+// other route if the variant was served by another route than the clean path.
+func variantOutcome(w *httptest.ResponseRecorder, v pathVariant) string {
+	if rt := w.Header().Get(routeHeader); rt != "" && rt != v.route.method+" "+v.route.path {
+		return "other route"
+	}
+	outcome := strconv.Itoa(w.Code)
+	if w.Code < 300 || w.Code >= 400 {
+		return outcome
+	}
+	location, err := url.Parse(w.Header().Get("Location"))
+	if err == nil && location.Path == v.clean {
+		return outcome + " to clean path"
+	}
+	return outcome + " elsewhere"
+}
+
+// TestPathVariants prints with -v how the routers answer requests for
+// variants of the paths of the routes: a 2xx status code means the router
+// served the variant with the route of the clean path, other route that
+// another route served it, a redirect is listed with whether it redirects to
+// the clean path and everything else is a rejection.
+func TestPathVariants(t *testing.T) {
+	var rows [][]string
+	for _, router := range routers {
+		if router.skipTest || router.skipUnmatched {
+			continue
+		}
+
+		outcomes := classCounts(len(variantClasses))
+		for _, api := range apis {
+			if !api.supportedBy(router) {
+				continue
+			}
+			h := router.build(api.routes, testHandler)
+
+			for c, variants := range api.variants {
+				for _, v := range variants {
+					r, _ := http.NewRequest(v.request.method, "/", nil)
+					r.URL.Path = v.request.path
+					r.RequestURI = v.request.path
+					w := httptest.NewRecorder()
+					h.ServeHTTP(w, r)
+					outcomes[c][variantOutcome(w, v)]++
+				}
+			}
+		}
+
+		rows = append(rows, classRow(router.name, outcomes))
+	}
+
+	if testing.Verbose() {
+		header := []string{"Router"}
+		for _, class := range variantClasses {
+			header = append(header, class.name)
+		}
+		if err := writeMarkdownTable(os.Stdout, header, rows); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
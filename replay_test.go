@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var replayFiles fileList
+
+func init() {
+	flag.Var(&replayFiles, "replay", "replay the access log in `API=file` against the routers loaded with the API; may be repeated")
+}
+
+// replay is the traffic recorded in an access log, which is replayed
+// against the routers loaded with an API.
+type replay struct {
+	name     string
+	requests []sentRequest
+}
+
+func newReplayRequest(method, target string) (sentRequest, error) {
+	u, err := url.ParseRequestURI(target)
+	if err != nil {
+		return sentRequest{}, err
+	}
+	if !strings.HasPrefix(u.Path, "/") {
+		return sentRequest{}, fmt.Errorf("no path in %q", target)
+	}
+	return sentRequest{
+		method:     method,
+		requestURI: u.RequestURI(),
+		path:       u.Path,
+		rawPath:    u.RawPath,
+		rawQuery:   u.RawQuery,
+	}, nil
+}
+
+// loadReplays adds the access logs given by the replay flag to their APIs.
+// HAR files are recognized by their .har extension, all other files are
+// read in the Common or Combined Log Format.
+func loadReplays() error {
+	for _, arg := range replayFiles {
+		i := strings.IndexByte(arg, '=')
+		if i < 0 {
+			return fmt.Errorf("%s: expected API=file", arg)
+		}
+		name, file := arg[:i], arg[i+1:]
+
+		var a *api
+		for _, other := range apis {
+			if other.name == name {
+				a = other
+			}
+		}
+		if a == nil {
+			return fmt.Errorf("%s: unknown API %s", file, name)
+		}
+
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		ext := filepath.Ext(file)
+		var requests []sentRequest
+		var skipped int
+		if strings.EqualFold(ext, ".har") {
+			requests, skipped, err = parseHAR(f)
+		} else {
+			requests, skipped, err = parseAccessLog(f)
+		}
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %v", file, err)
+		}
+		if len(requests) == 0 {
+			return fmt.Errorf("%s: no requests", file)
+		}
+		if skipped > 0 {
+			fmt.Fprintf(os.Stderr, "%s: skipped %d malformed requests\n", file, skipped)
+		}
+
+		a.replays = append(a.replays, &replay{
+			name:     strings.TrimSuffix(filepath.Base(file), ext),
+			requests: requests,
+		})
+	}
+	return nil
+}
+
+// parseAccessLog reads the requests of an access log in the Common or
+// Combined Log Format, e.g.
+//
+//	127.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "GET /users/gordon HTTP/1.1" 200 2326
+//
+// Lines without a valid request line are skipped.
+func parseAccessLog(r io.Reader) (requests []sentRequest, skipped int, err error) {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+	for s.Scan() {
+		line := s.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		req, err := parseLogLine(line)
+		if err != nil {
+			skipped++
+			continue
+		}
+		requests = append(requests, req)
+	}
+	return requests, skipped, s.Err()
+}
+
+func parseLogLine(line string) (sentRequest, error) {
+	start := strings.IndexByte(line, '"')
+	if start < 0 {
+		return sentRequest{}, errors.New("no request line")
+	}
+	end := strings.IndexByte(line[start+1:], '"')
+	if end < 0 {
+		return sentRequest{}, errors.New("unterminated request line")
+	}
+	fields := strings.Fields(line[start+1 : start+1+end])
+	if len(fields) != 3 {
+		return sentRequest{}, errors.New("malformed request line")
+	}
+	return newReplayRequest(fields[0], fields[1])
+}
+
+// har is the part of a HTTP Archive describing the requests.
+type har struct {
+	Log struct {
+		Entries []struct {
+			Request struct {
+				Method string `json:"method"`
+				URL    string `json:"url"`
+			} `json:"request"`
+		} `json:"entries"`
+	} `json:"log"`
+}
+
+// parseHAR reads the requests of a HTTP Archive. Entries without a valid
+// request are skipped.
+func parseHAR(r io.Reader) (requests []sentRequest, skipped int, err error) {
+	var doc har
+	if err = json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, 0, err
+	}
+	for _, entry := range doc.Log.Entries {
+		req, err := newReplayRequest(entry.Request.Method, entry.Request.URL)
+		if err != nil || req.method == "" {
+			skipped++
+			continue
+		}
+		requests = append(requests, req)
+	}
+	return requests, skipped, nil
+}
+
+// supported returns the requests of the replay the router can take and the
+// number of the others, which are requests no route matches for routers
+// with skipUnmatched and requests with a non-standard method for routers
+// with standardMethodsOnly.
+func (rp *replay) supported(router *routerAdapter, routes []route) (requests []sentRequest, dropped int) {
+	for _, req := range rp.requests {
+		if router.standardMethodsOnly && !contains(standardMethods, req.method) {
+			dropped++
+			continue
+		}
+		if router.skipUnmatched {
+			if _, ok := oracleMatch(routes, req.method, req.path); !ok {
+				dropped++
+				continue
+			}
+		}
+		requests = append(requests, req)
+	}
+	return requests, dropped
+}
+
+func TestParseAccessLog(t *testing.T) {
+	requests, skipped, err := parseAccessLog(strings.NewReader(`
+127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /users/gordon?page=2 HTTP/1.1" 200 2326
+127.0.0.1 - - [10/Oct/2000:13:55:37 -0700] "DELETE /gists/a%2Fb HTTP/1.1" 204 0 "http://example.com/" "curl/7.64.1"
+127.0.0.1 - - [10/Oct/2000:13:55:38 -0700] "-" 400 0
+127.0.0.1 - - [10/Oct/2000:13:55:39 -0700] "OPTIONS * HTTP/1.1" 200 0
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []sentRequest{
+		{"GET", "/users/gordon?page=2", "/users/gordon", "", "page=2"},
+		{"DELETE", "/gists/a%2Fb", "/gists/a/b", "/gists/a%2Fb", ""},
+	}
+	if !reflect.DeepEqual(requests, want) || skipped != 2 {
+		t.Errorf("got %v and %d skipped, want %v and 2 skipped", requests, skipped, want)
+	}
+}
+
+func TestParseHAR(t *testing.T) {
+	requests, skipped, err := parseHAR(strings.NewReader(`{"log": {"entries": [
+		{"request": {"method": "GET", "url": "https://api.github.com/user/repos"}},
+		{"request": {"method": "POST", "url": "https://api.github.com/gists?x=1"}},
+		{"request": {"method": "GET", "url": "not a url"}}
+	]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []sentRequest{
+		{"GET", "/user/repos", "/user/repos", "", ""},
+		{"POST", "/gists?x=1", "/gists", "", "x=1"},
+	}
+	if !reflect.DeepEqual(requests, want) || skipped != 1 {
+		t.Errorf("got %v and %d skipped, want %v and 1 skipped", requests, skipped, want)
+	}
+}
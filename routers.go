@@ -12,9 +12,11 @@ import (
 	"os"
 	"regexp"
 	"runtime"
+	"strconv"
+	"strings"
 
 	// If you add new routers please:
-	// - Keep the benchmark functions etc. alphabetically sorted
+	// - Keep the adapters etc. alphabetically sorted
 	// - Make a pull request (without benchmark results) at
 	//   https://github.com/julienschmidt/go-http-routing-benchmark
 	"github.com/ant0ine/go-json-rest/rest"
@@ -58,6 +60,7 @@ import (
 	goji "github.com/zenazn/goji/web"
 	gojiv2 "goji.io"
 	gojiv2pat "goji.io/pat"
+	gojiv2pattern "goji.io/pattern"
 	"gopkg.in/macaron.v1"
 )
 
@@ -66,6 +69,292 @@ type route struct {
 	path   string
 }
 
+// handlerMode selects the handler a router registers for its routes.
+type handlerMode int
+
+const (
+	// noopHandler does nothing at all. It is used by the benchmarks to
+	// measure the pure routing cost.
+	noopHandler handlerMode = iota
+
+	// writeHandler reads the "name" parameter through the router's native
+	// parameter API and writes its value to the response.
+	writeHandler
+
+	// testHandler writes the request URI to the response, which allows
+	// TestRouters to check that the request reached the handler of the
+	// expected route. Every route gets a handler of its own, see writeTest.
+	testHandler
+)
+
+const (
+	// paramsHeader lists the comma-separated names of the parameters a
+	// test handler writes to the response.
+	paramsHeader = "X-Test-Params"
+
+	// routeHeader is set by a test handler to the method and path of the
+	// route it was registered for, e.g. "GET /user/repos".
+	routeHeader = "X-Test-Route"
+)
+
+// writeTest writes the response of a test handler registered for the route.
+// It identifies the route in the routeHeader and writes the request URI,
+// followed by a name=value line for each parameter listed in the
+// paramsHeader of the request. param reads the value of a parameter through
+// the router's native parameter API.
+func writeTest(w http.ResponseWriter, r *http.Request, rt route, uri string, param func(name string) string) {
+	w.Header().Set(routeHeader, rt.method+" "+rt.path)
+	var sb strings.Builder
+	sb.WriteString(uri)
+	if names := r.Header.Get(paramsHeader); names != "" {
+		for _, name := range strings.Split(names, ",") {
+			sb.WriteString("\n" + name + "=" + param(name))
+		}
+	}
+	io.WriteString(w, sb.String())
+}
+
+// methodNotAllowed overrides the native option of routers which can answer
+// requests for a known path with an unregistered method with 405 Method Not
+// Allowed. If nil, the routers keep their default.
+var methodNotAllowed *bool
+
+// routerAdapter wires a router into the suite. The conformance test, the
+// memory measurement and all benchmarks are driven from the adapters
+// registered in routers.
+type routerAdapter struct {
+	name string
+
+	// load returns the router with all given routes registered, each one
+	// served by the handler selected by mode. Paths use the suite's
+	// canonical :param syntax and have to be translated by the adapter if
+	// the router expects a different one.
+	load func(routes []route, mode handlerMode) http.Handler
+
+	// staticOnly marks routers which do not support path parameters.
+	staticOnly bool
+
+	// anonymousParams marks routers which match parameters, but have no
+	// API to read their values by name.
+	anonymousParams bool
+
+	// maxParams is the maximum number of parameters of a route, if the
+	// router limits it.
+	maxParams int
+
+	// methods are the methods the router can register routes for, if it
+	// does not support all of them. Routes with other methods are skipped
+	// when the router is loaded.
+	methods []string
+
+	// catchAll marks routers which support catch-all parameters like
+	// /static/*filepath, which match the rest of the path. load has to
+	// translate them to the router's syntax.
+	catchAll bool
+
+	// constraint reports whether the router can express a parameter
+	// constrained by the regular expression re, e.g. [0-9]+ in
+	// :id([0-9]+). load has to translate constrained parameters. If nil,
+	// the router does not support constraints.
+	constraint func(re string) bool
+
+	// methodNotAllowedOption marks routers which have a native option for
+	// answering with 405 Method Not Allowed, which load has to set according
+	// to methodNotAllowed.
+	methodNotAllowedOption bool
+
+	// paramDelims are the characters besides / which end a parameter
+	// value. Requests with such characters in parameter values are not
+	// tested.
+	paramDelims string
+
+	// skipUnmatched excludes the router from the benchmarks and tests of
+	// requests which do not match any route, e.g. because it panics for
+	// some of them.
+	skipUnmatched bool
+
+	// standardMethodsOnly marks routers which panic for requests with a
+	// method other than the standard methods of net/http.
+	standardMethodsOnly bool
+
+	// skipTest excludes the router from TestRouters, e.g. because of known
+	// routing bugs. It is still benchmarked.
+	skipTest bool
+
+	// setup is called once before the router is loaded for the first time,
+	// teardown after all tests and benchmarks ran if the router was used.
+	// Both are optional.
+	setup    func()
+	teardown func()
+
+	// used is set once the router was loaded for the first time.
+	used bool
+}
+
+// build runs the setup if the router is used for the first time and then
+// loads it with the given routes, except those with a method it does not
+// handle. It should be used instead of calling load directly.
+func (a *routerAdapter) build(routes []route, mode handlerMode) http.Handler {
+	if !a.used {
+		if a.setup != nil {
+			a.setup()
+		}
+		a.used = true
+	}
+	return a.load(a.supportedRoutes(routes), mode)
+}
+
+// tryBuild is like build, but returns the panic of a router which fails to
+// register the routes, e.g. because they conflict, as error.
+func (a *routerAdapter) tryBuild(routes []route, mode handlerMode) (h http.Handler, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return a.build(routes, mode), nil
+}
+
+// handles reports whether the router can register routes for the method.
+func (a *routerAdapter) handles(method string) bool {
+	if a.methods == nil {
+		return true
+	}
+	for _, m := range a.methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// supportedRoutes returns the routes with a method the router handles. If
+// that are all, the routes are returned as they are.
+func (a *routerAdapter) supportedRoutes(routes []route) []route {
+	n := 0
+	for _, route := range routes {
+		if a.handles(route.method) {
+			n++
+		}
+	}
+	if n == len(routes) {
+		return routes
+	}
+	supported := make([]route, 0, n)
+	for _, route := range routes {
+		if a.handles(route.method) {
+			supported = append(supported, route)
+		}
+	}
+	return supported
+}
+
+// loadSingle returns the router with only a single route registered.
+func (a *routerAdapter) loadSingle(method, path string, mode handlerMode) http.Handler {
+	return a.build([]route{{method, path}}, mode)
+}
+
+// supports reports whether the router is able to serve all given routes.
+func (a *routerAdapter) supports(routes []route) bool {
+	for _, route := range routes {
+		switch {
+		case a.staticOnly && strings.ContainsAny(route.path, ":*"):
+			return false
+		case !a.catchAll && catchAllParam.MatchString(route.path):
+			return false
+		case a.maxParams > 0 && strings.Count(route.path, "/:")+strings.Count(route.path, "/*") > a.maxParams:
+			return false
+		}
+		for _, m := range paramPattern.FindAllStringSubmatch(route.path, -1) {
+			if m[2] != "" && (a.constraint == nil || !a.constraint(m[2])) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// paramPattern matches a parameter with its name and the regular expression
+// constraining it, if any, e.g. :id([0-9]+). Expressions can not contain a
+// slash.
+var paramPattern = regexp.MustCompile(`:([^/(]*)(?:\(([^/]*)\))?`)
+
+// catchAllParam matches a catch-all parameter, which is the last segment of
+// a path, with its name.
+var catchAllParam = regexp.MustCompile(`/\*([^/]*)$`)
+
+// translateParams replaces all parameters of the path by the result of
+// param, which is called with the name and the constraining regular
+// expression of the parameter, or an empty string if it is unconstrained.
+func translateParams(path string, param func(name, re string) string) string {
+	return paramPattern.ReplaceAllStringFunc(path, func(s string) string {
+		m := paramPattern.FindStringSubmatch(s)
+		return param(m[1], m[2])
+	})
+}
+
+// curlyParam translates a parameter to the {name} or {name:re} syntax.
+func curlyParam(name, re string) string {
+	if re == "" {
+		return "{" + name + "}"
+	}
+	return "{" + name + ":" + re + "}"
+}
+
+// anyConstraint is the constraint func of routers supporting arbitrary
+// regular expressions.
+func anyConstraint(string) bool {
+	return true
+}
+
+// routers are all registered routers, alphabetically sorted.
+var routers = []*routerAdapter{
+	{name: "Ace", load: loadAce, catchAll: true},
+	{name: "Aero", load: loadAero, catchAll: true, maxParams: 16, standardMethodsOnly: true},
+	{name: "Bear", load: loadBear, methods: []string{"GET", "POST", "PUT", "DELETE"}, catchAll: true},
+	{name: "Beego", load: loadBeego, catchAll: true, constraint: anyConstraint, setup: initBeego},
+	{name: "Bone", load: loadBone, catchAll: true, constraint: boneConstraint},
+	{name: "Chi", load: loadChi, catchAll: true, constraint: chiConstraint},
+	{name: "CloudyKitRouter", load: loadCloudyKitRouter, catchAll: true},
+	{name: "Denco", load: loadDenco, catchAll: true},
+	{name: "Echo", load: loadEcho, catchAll: true},
+	{name: "Gin", load: loadGin, catchAll: true, methodNotAllowedOption: true, setup: initGin},
+	{name: "GocraftWeb", load: loadGocraftWeb, catchAll: true, constraint: anyConstraint},
+	{name: "Goji", load: loadGoji, catchAll: true},
+	{name: "Gojiv2", load: loadGojiv2, catchAll: true},
+	{name: "GoJsonRest", load: loadGoJsonRest, catchAll: true},
+	{name: "GoRestful", load: loadGoRestful, catchAll: true, constraint: anyConstraint},
+	{name: "GorillaMux", load: loadGorillaMux, catchAll: true, constraint: anyConstraint},
+	{name: "GowwwRouter", load: loadGowwwRouter, catchAll: true},
+	{name: "HttpRouter", load: loadHttpRouter, catchAll: true, methodNotAllowedOption: true},
+	{name: "HttpServeMux", load: loadHttpServeMux, staticOnly: true},
+	{name: "HttpTreeMux", load: loadHttpTreeMux, catchAll: true},
+	{name: "Kocha", load: loadKocha, catchAll: true, paramDelims: ".", skipUnmatched: true},
+	{name: "LARS", load: loadLARS, catchAll: true, methodNotAllowedOption: true},
+	{name: "Macaron", load: loadMacaron, catchAll: true, constraint: macaronConstraint},
+	{name: "Martini", load: loadMartini, catchAll: true, constraint: anyConstraint, setup: initMartini},
+	{name: "Pat", load: loadPat, catchAll: true},
+	{name: "Possum", load: loadPossum, anonymousParams: true},
+	{name: "R2router", load: loadR2router, methodNotAllowedOption: true},
+	// {name: "Revel", load: loadRevel, setup: initRevel},
+	{name: "Rivet", load: loadRivet, catchAll: true},
+	{name: "Tango", load: loadTango, catchAll: true, setup: initTango, skipTest: true},
+	{name: "TigerTonic", load: loadTigerTonic},
+	{name: "Traffic", load: loadTraffic, catchAll: true, setup: initTraffic},
+	{name: "Vulcan", load: loadVulcan, anonymousParams: true, constraint: vulcanConstraint},
+	// {name: "Zeus", load: loadZeus},
+}
+
+// routerByName returns the registered router with the given name or nil.
+func routerByName(name string) *routerAdapter {
+	for _, r := range routers {
+		if r.name == name {
+			return r
+		}
+	}
+	return nil
+}
+
 type mockResponseWriter struct{}
 
 func (m *mockResponseWriter) Header() (h http.Header) {
@@ -84,9 +373,6 @@ func (m *mockResponseWriter) WriteHeader(int) {}
 
 var nullLogger *log.Logger
 
-// flag indicating if the normal or the test handler should be loaded
-var loadTestHandler = false
-
 func init() {
 	// beego sets it to runtime.NumCPU()
 	// Currently none of the contesters does concurrent routing
@@ -95,20 +381,15 @@ func init() {
 	// makes logging 'webscale' (ignores them)
 	log.SetOutput(new(mockResponseWriter))
 	nullLogger = log.New(new(mockResponseWriter), "", 0)
-
-	initBeego()
-	initGin()
-	initMartini()
-	// initRevel()
-	initTango()
-	initTraffic()
 }
 
 // Common
 func httpHandlerFunc(_ http.ResponseWriter, _ *http.Request) {}
 
-func httpHandlerFuncTest(w http.ResponseWriter, r *http.Request) {
-	io.WriteString(w, r.RequestURI)
+func httpHandlerFuncTest(rt route) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeTest(w, r, rt, r.RequestURI, nil)
+	}
 }
 
 // Ace
@@ -118,29 +399,28 @@ func aceHandleWrite(c *ace.C) {
 	io.WriteString(c.Writer, c.Param("name"))
 }
 
-func aceHandleTest(c *ace.C) {
-	io.WriteString(c.Writer, c.Request.RequestURI)
+func aceHandleTest(rt route) ace.HandlerFunc {
+	return func(c *ace.C) {
+		writeTest(c.Writer, c.Request, rt, c.Request.RequestURI, c.Param)
+	}
 }
 
-func loadAce(routes []route) http.Handler {
-	h := []ace.HandlerFunc{aceHandle}
-	if loadTestHandler {
-		h = []ace.HandlerFunc{aceHandleTest}
+func loadAce(routes []route, mode handlerMode) http.Handler {
+	h := aceHandle
+	if mode == writeHandler {
+		h = aceHandleWrite
 	}
 
 	router := ace.New()
 	for _, route := range routes {
-		router.Handle(route.method, route.path, h)
+		if mode == testHandler {
+			h = aceHandleTest(route)
+		}
+		router.Handle(route.method, route.path, []ace.HandlerFunc{h})
 	}
 	return router
 }
 
-func loadAceSingle(method, path string, handle ace.HandlerFunc) http.Handler {
-	router := ace.New()
-	router.Handle(method, path, []ace.HandlerFunc{handle})
-	return router
-}
-
 // Aero
 func aeroHandler(c aero.Context) error {
 	return nil
@@ -150,17 +430,25 @@ func aeroHandlerWrite(ctx aero.Context) error {
 	io.WriteString(ctx.Response().Internal(), ctx.Get("name"))
 	return nil
 }
-func aeroHandlerTest(ctx aero.Context) error {
-	io.WriteString(ctx.Response().Internal(), ctx.Request().Path())
-	return nil
+
+func aeroHandlerTest(rt route) aero.Handler {
+	return func(ctx aero.Context) error {
+		writeTest(ctx.Response().Internal(), ctx.Request().Internal(), rt, ctx.Request().Path(), ctx.Get)
+		return nil
+	}
 }
-func loadAero(routes []route) http.Handler {
+
+func loadAero(routes []route, mode handlerMode) http.Handler {
 	var h aero.Handler = aeroHandler
-	if loadTestHandler {
-		h = aeroHandlerTest
+	if mode == writeHandler {
+		h = aeroHandlerWrite
 	}
+
 	app := aero.New()
 	for _, r := range routes {
+		if mode == testHandler {
+			h = aeroHandlerTest(r)
+		}
 		switch r.method {
 		case "GET":
 			app.Get(r.path, h)
@@ -178,25 +466,6 @@ func loadAero(routes []route) http.Handler {
 	}
 	return app
 }
-func loadAeroSingle(method, path string, h aero.Handler) http.Handler {
-	app := aero.New()
-	switch method {
-	case "GET":
-		app.Get(path, h)
-	case "POST":
-		app.Post(path, h)
-	case "PUT":
-		app.Put(path, h)
-	case "PATCH":
-		app.Router().Add(http.MethodPatch, path, h)
-	case "DELETE":
-		app.Delete(path, h)
-	default:
-		panic("Unknow HTTP method: " + method)
-	}
-	// }
-	return app
-}
 
 // bear
 func bearHandler(_ http.ResponseWriter, _ *http.Request, _ *bear.Context) {}
@@ -205,22 +474,30 @@ func bearHandlerWrite(w http.ResponseWriter, _ *http.Request, ctx *bear.Context)
 	io.WriteString(w, ctx.Params["name"])
 }
 
-func bearHandlerTest(w http.ResponseWriter, r *http.Request, _ *bear.Context) {
-	io.WriteString(w, r.RequestURI)
+func bearHandlerTest(rt route) bear.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, ctx *bear.Context) {
+		writeTest(w, r, rt, r.RequestURI, func(name string) string {
+			return ctx.Params[name]
+		})
+	}
 }
 
-func loadBear(routes []route) http.Handler {
+func loadBear(routes []route, mode handlerMode) http.Handler {
 	h := bearHandler
-	if loadTestHandler {
-		h = bearHandlerTest
+	if mode == writeHandler {
+		h = bearHandlerWrite
 	}
 
 	router := bear.New()
 	re := regexp.MustCompile(":([^/]*)")
 	for _, route := range routes {
+		if mode == testHandler {
+			h = bearHandlerTest(route)
+		}
+		path := catchAllParam.ReplaceAllString(re.ReplaceAllString(route.path, "{$1}"), "/*")
 		switch route.method {
-		case "GET", "POST", "PUT", "PATCH", "DELETE":
-			router.On(route.method, re.ReplaceAllString(route.path, "{$1}"), h)
+		case "GET", "POST", "PUT", "DELETE":
+			router.On(route.method, path, h)
 		default:
 			panic("Unknown HTTP method: " + route.method)
 		}
@@ -228,17 +505,6 @@ func loadBear(routes []route) http.Handler {
 	return router
 }
 
-func loadBearSingle(method string, path string, handler bear.HandlerFunc) http.Handler {
-	router := bear.New()
-	switch method {
-	case "GET", "POST", "PUT", "PATCH", "DELETE":
-		router.On(method, path, handler)
-	default:
-		panic("Unknown HTTP method: " + method)
-	}
-	return router
-}
-
 // beego
 func beegoHandler(ctx *context.Context) {}
 
@@ -246,8 +512,12 @@ func beegoHandlerWrite(ctx *context.Context) {
 	ctx.WriteString(ctx.Input.Param(":name"))
 }
 
-func beegoHandlerTest(ctx *context.Context) {
-	ctx.WriteString(ctx.Request.RequestURI)
+func beegoHandlerTest(rt route) beego.FilterFunc {
+	return func(ctx *context.Context) {
+		writeTest(ctx.ResponseWriter, ctx.Request, rt, ctx.Request.RequestURI, func(name string) string {
+			return ctx.Input.Param(":" + name)
+		})
+	}
 }
 
 func initBeego() {
@@ -255,27 +525,37 @@ func initBeego() {
 	beego.BeeLogger.Close()
 }
 
-func loadBeego(routes []route) http.Handler {
+func loadBeego(routes []route, mode handlerMode) http.Handler {
 	h := beegoHandler
-	if loadTestHandler {
-		h = beegoHandlerTest
+	if mode == writeHandler {
+		h = beegoHandlerWrite
+	}
+
+	// a * in a constraint would be taken for a catch-all
+	param := func(name, re string) string {
+		if re == "" {
+			return ":" + name
+		}
+		return ":" + name + "(" + strings.Replace(re, "*", "{0,}", -1) + ")"
 	}
 
-	re := regexp.MustCompile(":([^/]*)")
 	app := beego.NewControllerRegister()
 	for _, route := range routes {
-		route.path = re.ReplaceAllString(route.path, ":$1")
+		if mode == testHandler {
+			h = beegoHandlerTest(route)
+		}
+		path := translateParams(catchAllParam.ReplaceAllString(route.path, "/*"), param)
 		switch route.method {
 		case "GET":
-			app.Get(route.path, h)
+			app.Get(path, h)
 		case "POST":
-			app.Post(route.path, h)
+			app.Post(path, h)
 		case "PUT":
-			app.Put(route.path, h)
+			app.Put(path, h)
 		case "PATCH":
-			app.Patch(route.path, h)
+			app.Patch(path, h)
 		case "DELETE":
-			app.Delete(route.path, h)
+			app.Delete(path, h)
 		default:
 			panic("Unknow HTTP method: " + route.method)
 		}
@@ -283,49 +563,50 @@ func loadBeego(routes []route) http.Handler {
 	return app
 }
 
-func loadBeegoSingle(method, path string, handler beego.FilterFunc) http.Handler {
-	app := beego.NewControllerRegister()
-	switch method {
-	case "GET":
-		app.Get(path, handler)
-	case "POST":
-		app.Post(path, handler)
-	case "PUT":
-		app.Put(path, handler)
-	case "PATCH":
-		app.Patch(path, handler)
-	case "DELETE":
-		app.Delete(path, handler)
-	default:
-		panic("Unknow HTTP method: " + method)
-	}
-	return app
-}
-
 // bone
 func boneHandlerWrite(rw http.ResponseWriter, req *http.Request) {
 	io.WriteString(rw, bone.GetValue(req, "name"))
 }
 
-func loadBone(routes []route) http.Handler {
+func boneHandlerTest(rt route) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		writeTest(rw, req, rt, req.RequestURI, func(name string) string {
+			return bone.GetValue(req, name)
+		})
+	}
+}
+
+func loadBone(routes []route, mode handlerMode) http.Handler {
 	h := http.HandlerFunc(httpHandlerFunc)
-	if loadTestHandler {
-		h = http.HandlerFunc(httpHandlerFuncTest)
+	if mode == writeHandler {
+		h = http.HandlerFunc(boneHandlerWrite)
+	}
+
+	param := func(name, re string) string {
+		if re == "" {
+			return ":" + name
+		}
+		// the final $ is cut off
+		return "#" + name + "^(?:" + re + ")$$"
 	}
 
 	router := bone.New()
 	for _, route := range routes {
+		if mode == testHandler {
+			h = boneHandlerTest(route)
+		}
+		path := translateParams(route.path, param)
 		switch route.method {
 		case "GET":
-			router.Get(route.path, h)
+			router.Get(path, h)
 		case "POST":
-			router.Post(route.path, h)
+			router.Post(path, h)
 		case "PUT":
-			router.Put(route.path, h)
+			router.Put(path, h)
 		case "PATCH":
-			router.Patch(route.path, h)
+			router.Patch(path, h)
 		case "DELETE":
-			router.Delete(route.path, h)
+			router.Delete(path, h)
 		default:
 			panic("Unknow HTTP method: " + route.method)
 		}
@@ -333,42 +614,35 @@ func loadBone(routes []route) http.Handler {
 	return router
 }
 
-func loadBoneSingle(method, path string, handler http.Handler) http.Handler {
-	router := bone.New()
-	switch method {
-	case "GET":
-		router.Get(path, handler)
-	case "POST":
-		router.Post(path, handler)
-	case "PUT":
-		router.Put(path, handler)
-	case "PATCH":
-		router.Patch(path, handler)
-	case "DELETE":
-		router.Delete(path, handler)
-	default:
-		panic("Unknow HTTP method: " + method)
-	}
-	return router
-}
-
-// chi
 // chi
 func chiHandleWrite(w http.ResponseWriter, r *http.Request) {
 	io.WriteString(w, chi.URLParam(r, "name"))
 }
 
-func loadChi(routes []route) http.Handler {
+func chiHandleTest(rt route) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeTest(w, r, rt, r.RequestURI, func(name string) string {
+			return chi.URLParam(r, name)
+		})
+	}
+}
+
+func loadChi(routes []route, mode handlerMode) http.Handler {
 	h := httpHandlerFunc
-	if loadTestHandler {
-		h = httpHandlerFuncTest
+	if mode == writeHandler {
+		h = chiHandleWrite
 	}
 
-	re := regexp.MustCompile(":([^/]*)")
+	param := func(name, re string) string {
+		return curlyParam(name, expandRepetitions(re))
+	}
 
 	mux := chi.NewRouter()
 	for _, route := range routes {
-		path := re.ReplaceAllString(route.path, "{$1}")
+		if mode == testHandler {
+			h = chiHandleTest(route)
+		}
+		path := catchAllParam.ReplaceAllString(translateParams(route.path, param), "/*")
 
 		switch route.method {
 		case "GET":
@@ -388,25 +662,6 @@ func loadChi(routes []route) http.Handler {
 	return mux
 }
 
-func loadChiSingle(method, path string, handler http.HandlerFunc) http.Handler {
-	mux := chi.NewRouter()
-	switch method {
-	case "GET":
-		mux.Get(path, handler)
-	case "POST":
-		mux.Post(path, handler)
-	case "PUT":
-		mux.Put(path, handler)
-	case "PATCH":
-		mux.Patch(path, handler)
-	case "DELETE":
-		mux.Delete(path, handler)
-	default:
-		panic("Unknown HTTP method: " + method)
-	}
-	return mux
-}
-
 // CloudyKit Router
 func cloudyKitRouterHandler(_ http.ResponseWriter, _ *http.Request, _ cloudykitrouter.Parameter) {}
 
@@ -414,29 +669,28 @@ func cloudyKitRouterHandlerWrite(w http.ResponseWriter, _ *http.Request, ps clou
 	io.WriteString(w, ps.ByName("name"))
 }
 
-func cloudyKitRouterHandlerTest(w http.ResponseWriter, r *http.Request, _ cloudykitrouter.Parameter) {
-	io.WriteString(w, r.RequestURI)
+func cloudyKitRouterHandlerTest(rt route) cloudykitrouter.Handler {
+	return func(w http.ResponseWriter, r *http.Request, ps cloudykitrouter.Parameter) {
+		writeTest(w, r, rt, r.RequestURI, ps.ByName)
+	}
 }
 
-func loadCloudyKitRouter(routes []route) http.Handler {
+func loadCloudyKitRouter(routes []route, mode handlerMode) http.Handler {
 	h := cloudyKitRouterHandler
-	if loadTestHandler {
-		h = cloudyKitRouterHandlerTest
+	if mode == writeHandler {
+		h = cloudyKitRouterHandlerWrite
 	}
 
 	router := cloudykitrouter.New()
 	for _, route := range routes {
+		if mode == testHandler {
+			h = cloudyKitRouterHandlerTest(route)
+		}
 		router.AddRoute(route.method, route.path, h)
 	}
 	return router
 }
 
-func loadCloudyKitRouterSingle(method, path string, handler cloudykitrouter.Handler) http.Handler {
-	router := cloudykitrouter.New()
-	router.AddRoute(method, path, handler)
-	return router
-}
-
 // Denco
 func dencoHandler(w http.ResponseWriter, r *http.Request, params denco.Params) {}
 
@@ -444,19 +698,24 @@ func dencoHandlerWrite(w http.ResponseWriter, r *http.Request, params denco.Para
 	io.WriteString(w, params.Get("name"))
 }
 
-func dencoHandlerTest(w http.ResponseWriter, r *http.Request, params denco.Params) {
-	io.WriteString(w, r.RequestURI)
+func dencoHandlerTest(rt route) denco.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, params denco.Params) {
+		writeTest(w, r, rt, r.RequestURI, params.Get)
+	}
 }
 
-func loadDenco(routes []route) http.Handler {
+func loadDenco(routes []route, mode handlerMode) http.Handler {
 	h := dencoHandler
-	if loadTestHandler {
-		h = dencoHandlerTest
+	if mode == writeHandler {
+		h = dencoHandlerWrite
 	}
 
 	mux := denco.NewMux()
 	handlers := make([]denco.Handler, 0, len(routes))
 	for _, route := range routes {
+		if mode == testHandler {
+			h = dencoHandlerTest(route)
+		}
 		handler := mux.Handler(route.method, route.path, h)
 		handlers = append(handlers, handler)
 	}
@@ -467,13 +726,29 @@ func loadDenco(routes []route) http.Handler {
 	return handler
 }
 
-func loadDencoSingle(method, path string, h denco.HandlerFunc) http.Handler {
-	mux := denco.NewMux()
-	handler, err := mux.Build([]denco.Handler{mux.Handler(method, path, h)})
-	if err != nil {
-		panic(err)
-	}
-	return handler
+// repetition matches a character class repeated a fixed number of times.
+var repetition = regexp.MustCompile(`(\[[^]]*\])\{([0-9]+)\}`)
+
+// expandRepetitions writes character classes repeated a fixed number of times,
+// e.g. [0-9]{3}, as [0-9][0-9][0-9].
+func expandRepetitions(re string) string {
+	return repetition.ReplaceAllStringFunc(re, func(s string) string {
+		m := repetition.FindStringSubmatch(s)
+		n, _ := strconv.Atoi(m[2])
+		return strings.Repeat(m[1], n)
+	})
+}
+
+// chiConstraint reports whether the constraint contains no braces once
+// repetitions are expanded, since chi ends a parameter at the first }.
+func chiConstraint(re string) bool {
+	return !strings.ContainsAny(expandRepetitions(re), "{}")
+}
+
+// boneConstraint reports whether the constraint does not contain a ^, which
+// bone takes for the start of the expression.
+func boneConstraint(re string) bool {
+	return !strings.Contains(re, "^")
 }
 
 // Echo
@@ -486,19 +761,24 @@ func echoHandlerWrite(c echo.Context) error {
 	return nil
 }
 
-func echoHandlerTest(c echo.Context) error {
-	io.WriteString(c.Response(), c.Request().RequestURI)
-	return nil
+func echoHandlerTest(rt route) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		writeTest(c.Response(), c.Request(), rt, c.Request().RequestURI, c.Param)
+		return nil
+	}
 }
 
-func loadEcho(routes []route) http.Handler {
+func loadEcho(routes []route, mode handlerMode) http.Handler {
 	var h echo.HandlerFunc = echoHandler
-	if loadTestHandler {
-		h = echoHandlerTest
+	if mode == writeHandler {
+		h = echoHandlerWrite
 	}
 
 	e := echo.New()
 	for _, r := range routes {
+		if mode == testHandler {
+			h = echoHandlerTest(r)
+		}
 		switch r.method {
 		case "GET":
 			e.GET(r.path, h)
@@ -517,25 +797,6 @@ func loadEcho(routes []route) http.Handler {
 	return e
 }
 
-func loadEchoSingle(method, path string, h echo.HandlerFunc) http.Handler {
-	e := echo.New()
-	switch method {
-	case "GET":
-		e.GET(path, h)
-	case "POST":
-		e.POST(path, h)
-	case "PUT":
-		e.PUT(path, h)
-	case "PATCH":
-		e.PATCH(path, h)
-	case "DELETE":
-		e.DELETE(path, h)
-	default:
-		panic("Unknow HTTP method: " + method)
-	}
-	return e
-}
-
 // Gin
 func ginHandle(_ *gin.Context) {}
 
@@ -543,33 +804,35 @@ func ginHandleWrite(c *gin.Context) {
 	io.WriteString(c.Writer, c.Params.ByName("name"))
 }
 
-func ginHandleTest(c *gin.Context) {
-	io.WriteString(c.Writer, c.Request.RequestURI)
+func ginHandleTest(rt route) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		writeTest(c.Writer, c.Request, rt, c.Request.RequestURI, c.Param)
+	}
 }
 
 func initGin() {
 	gin.SetMode(gin.ReleaseMode)
 }
 
-func loadGin(routes []route) http.Handler {
+func loadGin(routes []route, mode handlerMode) http.Handler {
 	h := ginHandle
-	if loadTestHandler {
-		h = ginHandleTest
+	if mode == writeHandler {
+		h = ginHandleWrite
 	}
 
 	router := gin.New()
+	if methodNotAllowed != nil {
+		router.HandleMethodNotAllowed = *methodNotAllowed
+	}
 	for _, route := range routes {
+		if mode == testHandler {
+			h = ginHandleTest(route)
+		}
 		router.Handle(route.method, route.path, h)
 	}
 	return router
 }
 
-func loadGinSingle(method, path string, handle gin.HandlerFunc) http.Handler {
-	router := gin.New()
-	router.Handle(method, path, handle)
-	return router
-}
-
 // gocraft/web
 type gocraftWebContext struct{}
 
@@ -579,29 +842,42 @@ func gocraftWebHandlerWrite(w web.ResponseWriter, r *web.Request) {
 	io.WriteString(w, r.PathParams["name"])
 }
 
-func gocraftWebHandlerTest(w web.ResponseWriter, r *web.Request) {
-	io.WriteString(w, r.RequestURI)
+func gocraftWebHandlerTest(rt route) func(web.ResponseWriter, *web.Request) {
+	return func(w web.ResponseWriter, r *web.Request) {
+		writeTest(w, r.Request, rt, r.RequestURI, func(name string) string {
+			return r.PathParams[name]
+		})
+	}
 }
 
-func loadGocraftWeb(routes []route) http.Handler {
+func loadGocraftWeb(routes []route, mode handlerMode) http.Handler {
 	h := gocraftWebHandler
-	if loadTestHandler {
-		h = gocraftWebHandlerTest
+	if mode == writeHandler {
+		h = gocraftWebHandlerWrite
 	}
 
 	router := web.New(gocraftWebContext{})
 	for _, route := range routes {
+		if mode == testHandler {
+			h = gocraftWebHandlerTest(route)
+		}
+		path := translateParams(catchAllParam.ReplaceAllString(route.path, "/:*"), func(name, re string) string {
+			if re == "" {
+				return ":" + name
+			}
+			return ":" + name + ":" + re
+		})
 		switch route.method {
 		case "GET":
-			router.Get(route.path, h)
+			router.Get(path, h)
 		case "POST":
-			router.Post(route.path, h)
+			router.Post(path, h)
 		case "PUT":
-			router.Put(route.path, h)
+			router.Put(path, h)
 		case "PATCH":
-			router.Patch(route.path, h)
+			router.Patch(path, h)
 		case "DELETE":
-			router.Delete(route.path, h)
+			router.Delete(path, h)
 		default:
 			panic("Unknow HTTP method: " + route.method)
 		}
@@ -609,49 +885,42 @@ func loadGocraftWeb(routes []route) http.Handler {
 	return router
 }
 
-func loadGocraftWebSingle(method, path string, handler interface{}) http.Handler {
-	router := web.New(gocraftWebContext{})
-	switch method {
-	case "GET":
-		router.Get(path, handler)
-	case "POST":
-		router.Post(path, handler)
-	case "PUT":
-		router.Put(path, handler)
-	case "PATCH":
-		router.Patch(path, handler)
-	case "DELETE":
-		router.Delete(path, handler)
-	default:
-		panic("Unknow HTTP method: " + method)
-	}
-	return router
-}
-
 // goji
 func gojiFuncWrite(c goji.C, w http.ResponseWriter, r *http.Request) {
 	io.WriteString(w, c.URLParams["name"])
 }
 
-func loadGoji(routes []route) http.Handler {
-	h := httpHandlerFunc
-	if loadTestHandler {
-		h = httpHandlerFuncTest
+func gojiFuncTest(rt route) func(goji.C, http.ResponseWriter, *http.Request) {
+	return func(c goji.C, w http.ResponseWriter, r *http.Request) {
+		writeTest(w, r, rt, r.RequestURI, func(name string) string {
+			return c.URLParams[name]
+		})
+	}
+}
+
+func loadGoji(routes []route, mode handlerMode) http.Handler {
+	var h interface{} = httpHandlerFunc
+	if mode == writeHandler {
+		h = gojiFuncWrite
 	}
 
 	mux := goji.New()
 	for _, route := range routes {
+		if mode == testHandler {
+			h = gojiFuncTest(route)
+		}
+		path := catchAllParam.ReplaceAllString(route.path, "/*")
 		switch route.method {
 		case "GET":
-			mux.Get(route.path, h)
+			mux.Get(path, h)
 		case "POST":
-			mux.Post(route.path, h)
+			mux.Post(path, h)
 		case "PUT":
-			mux.Put(route.path, h)
+			mux.Put(path, h)
 		case "PATCH":
-			mux.Patch(route.path, h)
+			mux.Patch(path, h)
 		case "DELETE":
-			mux.Delete(route.path, h)
+			mux.Delete(path, h)
 		default:
 			panic("Unknown HTTP method: " + route.method)
 		}
@@ -659,25 +928,6 @@ func loadGoji(routes []route) http.Handler {
 	return mux
 }
 
-func loadGojiSingle(method, path string, handler interface{}) http.Handler {
-	mux := goji.New()
-	switch method {
-	case "GET":
-		mux.Get(path, handler)
-	case "POST":
-		mux.Post(path, handler)
-	case "PUT":
-		mux.Put(path, handler)
-	case "PATCH":
-		mux.Patch(path, handler)
-	case "DELETE":
-		mux.Delete(path, handler)
-	default:
-		panic("Unknow HTTP method: " + method)
-	}
-	return mux
-}
-
 // goji v2 (github.com/goji/goji)
 func gojiv2Handler(w http.ResponseWriter, r *http.Request) {}
 
@@ -685,29 +935,39 @@ func gojiv2HandlerWrite(w http.ResponseWriter, r *http.Request) {
 	io.WriteString(w, gojiv2pat.Param(r, "name"))
 }
 
-func gojiv2HandlerTest(w http.ResponseWriter, r *http.Request) {
-	io.WriteString(w, r.RequestURI)
+func gojiv2HandlerTest(rt route) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeTest(w, r, rt, r.RequestURI, func(name string) string {
+			// pat.Param panics for names which are not bound
+			value, _ := r.Context().Value(gojiv2pattern.Variable(name)).(string)
+			return value
+		})
+	}
 }
 
-func loadGojiv2(routes []route) http.Handler {
+func loadGojiv2(routes []route, mode handlerMode) http.Handler {
 	h := gojiv2Handler
-	if loadTestHandler {
-		h = gojiv2HandlerTest
+	if mode == writeHandler {
+		h = gojiv2HandlerWrite
 	}
 
 	mux := gojiv2.NewMux()
 	for _, route := range routes {
+		if mode == testHandler {
+			h = gojiv2HandlerTest(route)
+		}
+		path := catchAllParam.ReplaceAllString(route.path, "/*")
 		switch route.method {
 		case "GET":
-			mux.HandleFunc(gojiv2pat.Get(route.path), h)
+			mux.HandleFunc(gojiv2pat.Get(path), h)
 		case "POST":
-			mux.HandleFunc(gojiv2pat.Post(route.path), h)
+			mux.HandleFunc(gojiv2pat.Post(path), h)
 		case "PUT":
-			mux.HandleFunc(gojiv2pat.Put(route.path), h)
+			mux.HandleFunc(gojiv2pat.Put(path), h)
 		case "PATCH":
-			mux.HandleFunc(gojiv2pat.Patch(route.path), h)
+			mux.HandleFunc(gojiv2pat.Patch(path), h)
 		case "DELETE":
-			mux.HandleFunc(gojiv2pat.Delete(route.path), h)
+			mux.HandleFunc(gojiv2pat.Delete(path), h)
 		default:
 			panic("Unknown HTTP method: " + route.method)
 		}
@@ -715,25 +975,6 @@ func loadGojiv2(routes []route) http.Handler {
 	return mux
 }
 
-func loadGojiv2Single(method, path string, handler func(http.ResponseWriter, *http.Request)) http.Handler {
-	mux := gojiv2.NewMux()
-	switch method {
-	case "GET":
-		mux.HandleFunc(gojiv2pat.Get(path), handler)
-	case "POST":
-		mux.HandleFunc(gojiv2pat.Post(path), handler)
-	case "PUT":
-		mux.HandleFunc(gojiv2pat.Put(path), handler)
-	case "PATCH":
-		mux.HandleFunc(gojiv2pat.Patch(path), handler)
-	case "DELETE":
-		mux.HandleFunc(gojiv2pat.Delete(path), handler)
-	default:
-		panic("Unknow HTTP method: " + method)
-	}
-	return mux
-}
-
 // go-json-rest/rest
 func goJsonRestHandler(w rest.ResponseWriter, req *rest.Request) {}
 
@@ -741,38 +982,34 @@ func goJsonRestHandlerWrite(w rest.ResponseWriter, req *rest.Request) {
 	io.WriteString(w.(io.Writer), req.PathParam("name"))
 }
 
-func goJsonRestHandlerTest(w rest.ResponseWriter, req *rest.Request) {
-	io.WriteString(w.(io.Writer), req.RequestURI)
+func goJsonRestHandlerTest(rt route) rest.HandlerFunc {
+	return func(w rest.ResponseWriter, req *rest.Request) {
+		writeTest(w.(http.ResponseWriter), req.Request, rt, req.RequestURI, req.PathParam)
+	}
 }
 
-func loadGoJsonRest(routes []route) http.Handler {
+func loadGoJsonRest(routes []route, mode handlerMode) http.Handler {
 	h := goJsonRestHandler
-	if loadTestHandler {
-		h = goJsonRestHandlerTest
+	if mode == writeHandler {
+		h = goJsonRestHandlerWrite
 	}
 
+	// #param matches everything up to the next /, :param stops at a dot
+	re := regexp.MustCompile(":([^/]*)")
+
 	api := rest.NewApi()
 	restRoutes := make([]*rest.Route, 0, len(routes))
 	for _, route := range routes {
+		if mode == testHandler {
+			h = goJsonRestHandlerTest(route)
+		}
 		restRoutes = append(restRoutes,
-			&rest.Route{route.method, route.path, h},
+			&rest.Route{HttpMethod: route.method, PathExp: re.ReplaceAllString(route.path, "#$1"), Func: h},
 		)
 	}
 	router, err := rest.MakeRouter(restRoutes...)
 	if err != nil {
-		log.Fatal(err)
-	}
-	api.SetApp(router)
-	return api.MakeHandler()
-}
-
-func loadGoJsonRestSingle(method, path string, hfunc rest.HandlerFunc) http.Handler {
-	api := rest.NewApi()
-	router, err := rest.MakeRouter(
-		&rest.Route{method, path, hfunc},
-	)
-	if err != nil {
-		log.Fatal(err)
+		panic(err)
 	}
 	api.SetApp(router)
 	return api.MakeHandler()
@@ -785,23 +1022,34 @@ func goRestfulHandlerWrite(r *restful.Request, w *restful.Response) {
 	io.WriteString(w, r.PathParameter("name"))
 }
 
-func goRestfulHandlerTest(r *restful.Request, w *restful.Response) {
-	io.WriteString(w, r.Request.RequestURI)
+func goRestfulHandlerTest(rt route) restful.RouteFunction {
+	return func(r *restful.Request, w *restful.Response) {
+		writeTest(w, r.Request, rt, r.Request.RequestURI, r.PathParameter)
+	}
 }
 
-func loadGoRestful(routes []route) http.Handler {
+func loadGoRestful(routes []route, mode handlerMode) http.Handler {
 	h := goRestfulHandler
-	if loadTestHandler {
-		h = goRestfulHandlerTest
+	if mode == writeHandler {
+		h = goRestfulHandlerWrite
 	}
 
-	re := regexp.MustCompile(":([^/]*)")
-
 	wsContainer := restful.NewContainer()
 	ws := new(restful.WebService)
 
+	// expressions match any part of the segment
+	param := func(name, re string) string {
+		if re != "" {
+			re = "^(?:" + re + ")$"
+		}
+		return curlyParam(name, re)
+	}
+
 	for _, route := range routes {
-		path := re.ReplaceAllString(route.path, "{$1}")
+		if mode == testHandler {
+			h = goRestfulHandlerTest(route)
+		}
+		path := catchAllParam.ReplaceAllString(translateParams(route.path, param), "/{$1:*}")
 
 		switch route.method {
 		case "GET":
@@ -822,80 +1070,71 @@ func loadGoRestful(routes []route) http.Handler {
 	return wsContainer
 }
 
-func loadGoRestfulSingle(method, path string, handler restful.RouteFunction) http.Handler {
-	wsContainer := restful.NewContainer()
-	ws := new(restful.WebService)
-	switch method {
-	case "GET":
-		ws.Route(ws.GET(path).To(handler))
-	case "POST":
-		ws.Route(ws.POST(path).To(handler))
-	case "PUT":
-		ws.Route(ws.PUT(path).To(handler))
-	case "PATCH":
-		ws.Route(ws.PATCH(path).To(handler))
-	case "DELETE":
-		ws.Route(ws.DELETE(path).To(handler))
-	default:
-		panic("Unknow HTTP method: " + method)
-	}
-	wsContainer.Add(ws)
-	return wsContainer
-}
-
 // gorilla/mux
 func gorillaHandlerWrite(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	io.WriteString(w, params["name"])
 }
 
-func loadGorillaMux(routes []route) http.Handler {
+func gorillaHandlerTest(rt route) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		writeTest(w, r, rt, r.RequestURI, func(name string) string {
+			return params[name]
+		})
+	}
+}
+
+func loadGorillaMux(routes []route, mode handlerMode) http.Handler {
 	h := httpHandlerFunc
-	if loadTestHandler {
-		h = httpHandlerFuncTest
+	if mode == writeHandler {
+		h = gorillaHandlerWrite
 	}
 
-	re := regexp.MustCompile(":([^/]*)")
 	m := mux.NewRouter()
 	for _, route := range routes {
+		if mode == testHandler {
+			h = gorillaHandlerTest(route)
+		}
 		m.HandleFunc(
-			re.ReplaceAllString(route.path, "{$1}"),
+			catchAllParam.ReplaceAllString(translateParams(route.path, curlyParam), "/{$1:.*}"),
 			h,
 		).Methods(route.method)
 	}
 	return m
 }
 
-func loadGorillaMuxSingle(method, path string, handler http.HandlerFunc) http.Handler {
-	m := mux.NewRouter()
-	m.HandleFunc(path, handler).Methods(method)
-	return m
-}
-
 // gowww/router
 func gowwwRouterHandleWrite(w http.ResponseWriter, r *http.Request) {
 	io.WriteString(w, gowwwrouter.Parameter(r, "name"))
 }
 
-func loadGowwwRouter(routes []route) http.Handler {
+func gowwwRouterHandleTest(rt route) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeTest(w, r, rt, r.RequestURI, func(name string) string {
+			return gowwwrouter.Parameter(r, name)
+		})
+	}
+}
+
+func loadGowwwRouter(routes []route, mode handlerMode) http.Handler {
 	h := httpHandlerFunc
-	if loadTestHandler {
-		h = httpHandlerFuncTest
+	if mode == writeHandler {
+		h = gowwwRouterHandleWrite
 	}
 
+	// paths ending in a slash match the whole subtree
+
 	router := gowwwrouter.New()
 	for _, route := range routes {
-		router.Handle(route.method, route.path, http.HandlerFunc(h))
+		if mode == testHandler {
+			h = gowwwRouterHandleTest(route)
+		}
+		router.Handle(route.method, catchAllParam.ReplaceAllString(route.path, "/"), http.HandlerFunc(h))
 	}
 	return router
 }
 
-func loadGowwwRouterSingle(method, path string, handler http.Handler) http.Handler {
-	router := gowwwrouter.New()
-	router.Handle(method, path, handler)
-	return router
-}
-
 // HttpRouter
 func httpRouterHandle(_ http.ResponseWriter, _ *http.Request, _ httprouter.Params) {}
 
@@ -903,27 +1142,54 @@ func httpRouterHandleWrite(w http.ResponseWriter, _ *http.Request, ps httprouter
 	io.WriteString(w, ps.ByName("name"))
 }
 
-func httpRouterHandleTest(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-	io.WriteString(w, r.RequestURI)
+func httpRouterHandleTest(rt route) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		writeTest(w, r, rt, r.RequestURI, ps.ByName)
+	}
 }
 
-func loadHttpRouter(routes []route) http.Handler {
+func loadHttpRouter(routes []route, mode handlerMode) http.Handler {
 	h := httpRouterHandle
-	if loadTestHandler {
-		h = httpRouterHandleTest
+	if mode == writeHandler {
+		h = httpRouterHandleWrite
 	}
 
 	router := httprouter.New()
+	if methodNotAllowed != nil {
+		router.HandleMethodNotAllowed = *methodNotAllowed
+	}
 	for _, route := range routes {
+		if mode == testHandler {
+			h = httpRouterHandleTest(route)
+		}
 		router.Handle(route.method, route.path, h)
 	}
 	return router
 }
 
-func loadHttpRouterSingle(method, path string, handle httprouter.Handle) http.Handler {
-	router := httprouter.New()
-	router.Handle(method, path, handle)
-	return router
+// http.ServeMux
+func loadHttpServeMux(routes []route, mode handlerMode) http.Handler {
+	serveMux := http.NewServeMux()
+	for _, route := range routes {
+		h := httpHandlerFunc
+		if mode == testHandler {
+			h = httpHandlerFuncTest(route)
+		}
+
+		// patterns ending in a slash match the whole subtree
+		if path := route.path; strings.HasSuffix(path, "/") {
+			serveMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != path {
+					http.NotFound(w, r)
+					return
+				}
+				h(w, r)
+			})
+			continue
+		}
+		serveMux.HandleFunc(route.path, h)
+	}
+	return serveMux
 }
 
 // httpTreeMux
@@ -933,29 +1199,30 @@ func httpTreeMuxHandlerWrite(w http.ResponseWriter, _ *http.Request, vars map[st
 	io.WriteString(w, vars["name"])
 }
 
-func httpTreeMuxHandlerTest(w http.ResponseWriter, r *http.Request, _ map[string]string) {
-	io.WriteString(w, r.RequestURI)
+func httpTreeMuxHandlerTest(rt route) httptreemux.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+		writeTest(w, r, rt, r.RequestURI, func(name string) string {
+			return vars[name]
+		})
+	}
 }
 
-func loadHttpTreeMux(routes []route) http.Handler {
+func loadHttpTreeMux(routes []route, mode handlerMode) http.Handler {
 	h := httpTreeMuxHandler
-	if loadTestHandler {
-		h = httpTreeMuxHandlerTest
+	if mode == writeHandler {
+		h = httpTreeMuxHandlerWrite
 	}
 
 	router := httptreemux.New()
 	for _, route := range routes {
+		if mode == testHandler {
+			h = httpTreeMuxHandlerTest(route)
+		}
 		router.Handle(route.method, route.path, h)
 	}
 	return router
 }
 
-func loadHttpTreeMuxSingle(method, path string, handler httptreemux.HandlerFunc) http.Handler {
-	router := httptreemux.New()
-	router.Handle(method, path, handler)
-	return router
-}
-
 // Kocha-urlrouter
 type kochaHandler struct {
 	routerMap map[string]urlrouter.URLRouter
@@ -963,7 +1230,16 @@ type kochaHandler struct {
 }
 
 func (h *kochaHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	meth, params := h.routerMap[r.Method].Lookup(r.URL.Path)
+	router, ok := h.routerMap[r.Method]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	meth, params := router.Lookup(r.URL.Path)
+	if meth == nil {
+		http.NotFound(w, r)
+		return
+	}
 	h.params = params
 	meth.(http.HandlerFunc).ServeHTTP(w, r)
 }
@@ -974,29 +1250,26 @@ func (h *kochaHandler) Put(w http.ResponseWriter, r *http.Request)    {}
 func (h *kochaHandler) Patch(w http.ResponseWriter, r *http.Request)  {}
 func (h *kochaHandler) Delete(w http.ResponseWriter, r *http.Request) {}
 func (h *kochaHandler) kochaHandlerWrite(w http.ResponseWriter, r *http.Request) {
-	var name string
+	io.WriteString(w, h.param("name"))
+}
+
+func (h *kochaHandler) kochaHandlerTest(rt route) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeTest(w, r, rt, r.RequestURI, h.param)
+	}
+}
+
+func (h *kochaHandler) param(name string) string {
 	for _, param := range h.params {
-		if param.Name == "name" {
-			name = param.Value
-			break
+		if param.Name == name {
+			return param.Value
 		}
 	}
-	io.WriteString(w, name)
+	return ""
 }
 
-func loadKocha(routes []route) http.Handler {
-	/*h := httpRouterHandle
-	if loadTestHandler {
-		h = httpRouterHandleTest
-	}*/
-
-	handler := &kochaHandler{routerMap: map[string]urlrouter.URLRouter{
-		"GET":    urlrouter.NewURLRouter("doublearray"),
-		"POST":   urlrouter.NewURLRouter("doublearray"),
-		"PUT":    urlrouter.NewURLRouter("doublearray"),
-		"PATCH":  urlrouter.NewURLRouter("doublearray"),
-		"DELETE": urlrouter.NewURLRouter("doublearray"),
-	}}
+func loadKocha(routes []route, mode handlerMode) http.Handler {
+	handler := &kochaHandler{routerMap: make(map[string]urlrouter.URLRouter)}
 	recordMap := make(map[string][]urlrouter.Record)
 	for _, route := range routes {
 		var f http.HandlerFunc
@@ -1012,28 +1285,23 @@ func loadKocha(routes []route) http.Handler {
 		case "DELETE":
 			f = handler.Delete
 		}
+		switch mode {
+		case writeHandler:
+			f = handler.kochaHandlerWrite
+		case testHandler:
+			f = handler.kochaHandlerTest(route)
+		}
 		recordMap[route.method] = append(
 			recordMap[route.method],
 			urlrouter.NewRecord(route.path, f),
 		)
 	}
 	for method, records := range recordMap {
-		if err := handler.routerMap[method].Build(records); err != nil {
+		router := urlrouter.NewURLRouter("doublearray")
+		if err := router.Build(records); err != nil {
 			panic(err)
 		}
-	}
-	return handler
-}
-
-func loadKochaSingle(method, path string, handler *kochaHandler, hfunc http.HandlerFunc) http.Handler {
-	handler.routerMap = map[string]urlrouter.URLRouter{
-		method: urlrouter.NewURLRouter("doublearray"),
-	}
-
-	if err := handler.routerMap[method].Build([]urlrouter.Record{
-		urlrouter.NewRecord(path, hfunc),
-	}); err != nil {
-		panic(err)
+		handler.routerMap[method] = router
 	}
 	return handler
 }
@@ -1046,23 +1314,27 @@ func larsHandlerWrite(c lars.Context) {
 	io.WriteString(c.Response(), c.Param("name"))
 }
 
-func larsHandlerTest(c lars.Context) {
-	io.WriteString(c.Response(), c.Request().RequestURI)
-}
-
-func larsNativeHandlerTest(w http.ResponseWriter, r *http.Request) {
-	io.WriteString(w, r.RequestURI)
+func larsHandlerTest(rt route) func(lars.Context) {
+	return func(c lars.Context) {
+		writeTest(c.Response(), c.Request(), rt, c.Request().RequestURI, c.Param)
+	}
 }
 
-func loadLARS(routes []route) http.Handler {
+func loadLARS(routes []route, mode handlerMode) http.Handler {
 	var h interface{} = larsHandler
-	if loadTestHandler {
-		h = larsHandlerTest
+	if mode == writeHandler {
+		h = larsHandlerWrite
 	}
 
 	l := lars.New()
+	if methodNotAllowed != nil {
+		l.SetHandle405MethodNotAllowed(*methodNotAllowed)
+	}
 
 	for _, r := range routes {
+		if mode == testHandler {
+			h = larsHandlerTest(r)
+		}
 		switch r.method {
 		case "GET":
 			l.Get(r.path, h)
@@ -1081,26 +1353,6 @@ func loadLARS(routes []route) http.Handler {
 	return l.Serve()
 }
 
-func loadLARSSingle(method, path string, h interface{}) http.Handler {
-	l := lars.New()
-
-	switch method {
-	case "GET":
-		l.Get(path, h)
-	case "POST":
-		l.Post(path, h)
-	case "PUT":
-		l.Put(path, h)
-	case "PATCH":
-		l.Patch(path, h)
-	case "DELETE":
-		l.Delete(path, h)
-	default:
-		panic("Unknow HTTP method: " + method)
-	}
-	return l.Serve()
-}
-
 // Macaron
 func macaronHandler() {}
 
@@ -1108,27 +1360,45 @@ func macaronHandlerWrite(c *macaron.Context) string {
 	return c.Params("name")
 }
 
-func macaronHandlerTest(c *macaron.Context) string {
-	return c.Req.RequestURI
+func macaronHandlerTest(rt route) func(*macaron.Context) {
+	return func(c *macaron.Context) {
+		writeTest(c.Resp, c.Req.Request, rt, c.Req.RequestURI, func(name string) string {
+			return c.Params(strings.Replace(name, "_", "", -1))
+		})
+	}
 }
 
-func loadMacaron(routes []route) http.Handler {
-	var h = []macaron.Handler{macaronHandler}
-	if loadTestHandler {
-		h[0] = macaronHandlerTest
+func loadMacaron(routes []route, mode handlerMode) http.Handler {
+	var h macaron.Handler = macaronHandler
+	if mode == writeHandler {
+		h = macaronHandlerWrite
+	}
+
+	// parameter names may only contain letters and digits
+	param := func(name, re string) string {
+		name = ":" + strings.Replace(name, "_", "", -1)
+		if re == "" {
+			return name
+		}
+		// expressions are not anchored and can not be grouped
+		return name + "(^" + re + "$)"
 	}
 
 	m := macaron.New()
 	for _, route := range routes {
-		m.Handle(route.method, route.path, h)
+		path := catchAllParam.ReplaceAllString(translateParams(route.path, param), "/*")
+		if mode == testHandler {
+			h = macaronHandlerTest(route)
+		}
+		m.Handle(route.method, path, []macaron.Handler{h})
 	}
 	return m
 }
 
-func loadMacaronSingle(method, path string, handler interface{}) http.Handler {
-	m := macaron.New()
-	m.Handle(method, path, []macaron.Handler{handler})
-	return m
+// macaronConstraint reports whether the constraint can be anchored without
+// grouping it, since Macaron ends an expression at the first ).
+func macaronConstraint(re string) bool {
+	return !strings.ContainsAny(re, "()|")
 }
 
 // Martini
@@ -1138,29 +1408,46 @@ func martiniHandlerWrite(params martini.Params) string {
 	return params["name"]
 }
 
+func martiniHandlerTest(rt route) func(http.ResponseWriter, *http.Request, martini.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params martini.Params) {
+		writeTest(w, r, rt, r.RequestURI, func(name string) string {
+			return params[name]
+		})
+	}
+}
+
 func initMartini() {
 	martini.Env = martini.Prod
 }
 
-func loadMartini(routes []route) http.Handler {
+func loadMartini(routes []route, mode handlerMode) http.Handler {
 	var h interface{} = martiniHandler
-	if loadTestHandler {
-		h = httpHandlerFuncTest
+	if mode == writeHandler {
+		h = martiniHandlerWrite
 	}
 
 	router := martini.NewRouter()
 	for _, route := range routes {
+		if mode == testHandler {
+			h = martiniHandlerTest(route)
+		}
+		path := translateParams(catchAllParam.ReplaceAllString(route.path, "/**"), func(name, re string) string {
+			if re == "" {
+				return ":" + name
+			}
+			return "(?P<" + name + ">" + re + ")"
+		})
 		switch route.method {
 		case "GET":
-			router.Get(route.path, h)
+			router.Get(path, h)
 		case "POST":
-			router.Post(route.path, h)
+			router.Post(path, h)
 		case "PUT":
-			router.Put(route.path, h)
+			router.Put(path, h)
 		case "PATCH":
-			router.Patch(route.path, h)
+			router.Patch(path, h)
 		case "DELETE":
-			router.Delete(route.path, h)
+			router.Delete(path, h)
 		default:
 			panic("Unknow HTTP method: " + route.method)
 		}
@@ -1170,50 +1457,45 @@ func loadMartini(routes []route) http.Handler {
 	return martini
 }
 
-func loadMartiniSingle(method, path string, handler interface{}) http.Handler {
-	router := martini.NewRouter()
-	switch method {
-	case "GET":
-		router.Get(path, handler)
-	case "POST":
-		router.Post(path, handler)
-	case "PUT":
-		router.Put(path, handler)
-	case "PATCH":
-		router.Patch(path, handler)
-	case "DELETE":
-		router.Delete(path, handler)
-	default:
-		panic("Unknow HTTP method: " + method)
-	}
-
-	martini := martini.New()
-	martini.Action(router.Handle)
-	return martini
-}
-
 // pat
 func patHandlerWrite(w http.ResponseWriter, r *http.Request) {
 	io.WriteString(w, r.URL.Query().Get(":name"))
 }
 
-func loadPat(routes []route) http.Handler {
+func patHandlerTest(rt route) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		writeTest(w, r, rt, r.RequestURI, func(name string) string {
+			return query.Get(":" + name)
+		})
+	}
+}
+
+func loadPat(routes []route, mode handlerMode) http.Handler {
 	h := http.HandlerFunc(httpHandlerFunc)
-	if loadTestHandler {
-		h = http.HandlerFunc(httpHandlerFuncTest)
+	if mode == writeHandler {
+		h = http.HandlerFunc(patHandlerWrite)
 	}
 
+	// paths ending in a slash match the whole subtree
+
 	m := pat.New()
 	for _, route := range routes {
+		if mode == testHandler {
+			h = patHandlerTest(route)
+		}
+		path := catchAllParam.ReplaceAllString(route.path, "/")
 		switch route.method {
 		case "GET":
-			m.Get(route.path, h)
+			m.Get(path, h)
 		case "POST":
-			m.Post(route.path, h)
+			m.Post(path, h)
 		case "PUT":
-			m.Put(route.path, h)
+			m.Put(path, h)
+		case "PATCH":
+			m.Patch(path, h)
 		case "DELETE":
-			m.Del(route.path, h)
+			m.Del(path, h)
 		default:
 			panic("Unknow HTTP method: " + route.method)
 		}
@@ -1221,23 +1503,6 @@ func loadPat(routes []route) http.Handler {
 	return m
 }
 
-func loadPatSingle(method, path string, handler http.Handler) http.Handler {
-	m := pat.New()
-	switch method {
-	case "GET":
-		m.Get(path, handler)
-	case "POST":
-		m.Post(path, handler)
-	case "PUT":
-		m.Put(path, handler)
-	case "DELETE":
-		m.Del(path, handler)
-	default:
-		panic("Unknow HTTP method: " + method)
-	}
-	return m
-}
-
 // Possum
 func possumHandler(c *possum.Context) error {
 	return nil
@@ -1248,28 +1513,53 @@ func possumHandlerWrite(c *possum.Context) error {
 	return nil
 }
 
-func possumHandlerTest(c *possum.Context) error {
-	io.WriteString(c.Response, c.Request.RequestURI)
-	return nil
+func possumHandlerTest(rt route) possum.HandlerFunc {
+	return func(c *possum.Context) error {
+		writeTest(c.Response, c.Request, rt, c.Request.RequestURI, nil)
+		return nil
+	}
 }
 
-func loadPossum(routes []route) http.Handler {
+func loadPossum(routes []route, mode handlerMode) http.Handler {
 	h := possumHandler
-	if loadTestHandler {
-		h = possumHandlerTest
+	if mode == writeHandler {
+		h = possumHandlerWrite
 	}
 
-	router := possum.NewServerMux()
+	// Possum's Colon and Brace routers expect /name/value pairs, only the
+	// Wildcard router matches parameters by position.
+	re := regexp.MustCompile(":[^/]*")
+
+	// Possum ignores the method, so there is a router per method
+	mux := make(possumMux)
 	for _, route := range routes {
-		router.HandleFunc(possumrouter.Simple(route.path), h, possumview.Simple("text/html", "utf-8"))
+		if mode == testHandler {
+			h = possumHandlerTest(route)
+		}
+		var r possumrouter.Router = possumrouter.Simple(route.path)
+		if strings.Contains(route.path, ":") {
+			r = possumrouter.Wildcard(re.ReplaceAllString(route.path, "*"))
+		}
+		router, ok := mux[route.method]
+		if !ok {
+			router = possum.NewServerMux()
+			mux[route.method] = router
+		}
+		router.HandleFunc(r, h, possumview.Simple("text/html", "utf-8"))
 	}
-	return router
+	return mux
 }
 
-func loadPossumSingle(method, path string, handler possum.HandlerFunc) http.Handler {
-	router := possum.NewServerMux()
-	router.HandleFunc(possumrouter.Simple(path), handler, possumview.Simple("text/html", "utf-8"))
-	return router
+// possumMux dispatches requests to the Possum router of their method.
+type possumMux map[string]*possum.ServerMux
+
+func (mux possumMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	router, ok := mux[r.Method]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	router.ServeHTTP(w, r)
 }
 
 // R2router
@@ -1279,29 +1569,31 @@ func r2routerHandleWrite(w http.ResponseWriter, req *http.Request, params r2rout
 	io.WriteString(w, params.Get("name"))
 }
 
-func r2routerHandleTest(w http.ResponseWriter, req *http.Request, _ r2router.Params) {
-	io.WriteString(w, req.RequestURI)
+func r2routerHandleTest(rt route) r2router.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request, params r2router.Params) {
+		writeTest(w, req, rt, req.RequestURI, params.Get)
+	}
 }
 
-func loadR2router(routes []route) http.Handler {
+func loadR2router(routes []route, mode handlerMode) http.Handler {
 	h := r2routerHandler
-	if loadTestHandler {
-		h = r2routerHandleTest
+	if mode == writeHandler {
+		h = r2routerHandleWrite
 	}
 
 	router := r2router.NewRouter()
+	if methodNotAllowed != nil {
+		router.HandleMethodNotAllowed = *methodNotAllowed
+	}
 	for _, r := range routes {
+		if mode == testHandler {
+			h = r2routerHandleTest(r)
+		}
 		router.AddHandler(r.method, r.path, h)
 	}
 	return router
 }
 
-func loadR2routerSingle(method, path string, handler r2router.HandlerFunc) http.Handler {
-	router := r2router.NewRouter()
-	router.AddHandler(method, path, handler)
-	return router
-}
-
 // Revel (Router only)
 // In the following code some Revel internals are modeled.
 // The original revel code is copyrighted by Rob Figueiredo.
@@ -1376,9 +1668,9 @@ func loadR2routerSingle(method, path string, handler r2router.HandlerFunc) http.
 // 		})
 // }
 
-// func loadRevel(routes []route) http.Handler {
+// func loadRevel(routes []route, mode handlerMode) http.Handler {
 // 	h := "RevelController.Handle"
-// 	if loadTestHandler {
+// 	if mode == testHandler {
 // 		h = "RevelController.HandleTest"
 // 	}
 
@@ -1410,19 +1702,6 @@ func loadR2routerSingle(method, path string, handler r2router.HandlerFunc) http.
 // 	return rc
 // }
 
-// func loadRevelSingle(method, path, action string) http.Handler {
-// 	router := revel.NewRouter("")
-
-// 	route := revel.NewRoute(method, path, action, "", "", 0)
-// 	if err := router.Tree.Add(route.TreePath, route); err != nil {
-// 		panic(err)
-// 	}
-
-// 	rc := new(RevelController)
-// 	rc.router = router
-// 	return rc
-// }
-
 // Rivet
 func rivetHandler() {}
 
@@ -1430,31 +1709,28 @@ func rivetHandlerWrite(c *rivet.Context) {
 	c.WriteString(c.Get("name"))
 }
 
-func rivetHandlerTest(c *rivet.Context) {
-	c.WriteString(c.Req.RequestURI)
+func rivetHandlerTest(rt route) func(*rivet.Context) {
+	return func(c *rivet.Context) {
+		writeTest(c.Res, c.Req, rt, c.Req.RequestURI, c.Get)
+	}
 }
 
-func loadRivet(routes []route) http.Handler {
+func loadRivet(routes []route, mode handlerMode) http.Handler {
 	var h interface{} = rivetHandler
-	if loadTestHandler {
-		h = rivetHandlerTest
+	if mode == writeHandler {
+		h = rivetHandlerWrite
 	}
 
 	router := rivet.New()
 	for _, route := range routes {
-		router.Handle(route.method, route.path, h)
+		if mode == testHandler {
+			h = rivetHandlerTest(route)
+		}
+		router.Handle(route.method, catchAllParam.ReplaceAllString(route.path, "/**"), h)
 	}
 	return router
 }
 
-func loadRivetSingle(method, path string, handler interface{}) http.Handler {
-	router := rivet.New()
-
-	router.Handle(method, path, handler)
-
-	return router
-}
-
 // Tango
 func tangoHandler(ctx *tango.Context) {}
 
@@ -1462,8 +1738,12 @@ func tangoHandlerWrite(ctx *tango.Context) {
 	ctx.Write([]byte(ctx.Params().Get(":name")))
 }
 
-func tangoHandlerTest(ctx *tango.Context) {
-	ctx.Write([]byte(ctx.Req().RequestURI))
+func tangoHandlerTest(rt route) func(*tango.Context) {
+	return func(ctx *tango.Context) {
+		writeTest(ctx.ResponseWriter, ctx.Req(), rt, ctx.Req().RequestURI, func(name string) string {
+			return ctx.Params().Get(":" + name)
+		})
+	}
 }
 
 func initTango() {
@@ -1471,50 +1751,50 @@ func initTango() {
 	llog.SetOutputLevel(llog.Lnone)
 }
 
-func loadTango(routes []route) http.Handler {
+func loadTango(routes []route, mode handlerMode) http.Handler {
 	h := tangoHandler
-	if loadTestHandler {
-		h = tangoHandlerTest
+	if mode == writeHandler {
+		h = tangoHandlerWrite
 	}
 
 	tg := tango.NewWithLog(llog.Std)
 	for _, route := range routes {
+		if mode == testHandler {
+			h = tangoHandlerTest(route)
+		}
 		tg.Route(route.method, route.path, h)
 	}
 	return tg
 }
 
-func loadTangoSingle(method, path string, handler func(*tango.Context)) http.Handler {
-	tg := tango.NewWithLog(llog.Std)
-	tg.Route(method, path, handler)
-	return tg
-}
-
 // Tiger Tonic
 func tigerTonicHandlerWrite(w http.ResponseWriter, r *http.Request) {
 	io.WriteString(w, r.URL.Query().Get("name"))
 }
 
-func loadTigerTonic(routes []route) http.Handler {
+func tigerTonicHandlerTest(rt route) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeTest(w, r, rt, r.RequestURI, r.URL.Query().Get)
+	}
+}
+
+func loadTigerTonic(routes []route, mode handlerMode) http.Handler {
 	h := httpHandlerFunc
-	if loadTestHandler {
-		h = httpHandlerFuncTest
+	if mode == writeHandler {
+		h = tigerTonicHandlerWrite
 	}
 
 	re := regexp.MustCompile(":([^/]*)")
 	mux := tigertonic.NewTrieServeMux()
 	for _, route := range routes {
+		if mode == testHandler {
+			h = tigerTonicHandlerTest(route)
+		}
 		mux.HandleFunc(route.method, re.ReplaceAllString(route.path, "{$1}"), h)
 	}
 	return mux
 }
 
-func loadTigerTonicSingle(method, path string, handler http.HandlerFunc) http.Handler {
-	mux := tigertonic.NewTrieServeMux()
-	mux.HandleFunc(method, path, handler)
-	return mux
-}
-
 // Traffic
 func trafficHandler(w traffic.ResponseWriter, r *traffic.Request) {}
 
@@ -1522,33 +1802,39 @@ func trafficHandlerWrite(w traffic.ResponseWriter, r *traffic.Request) {
 	io.WriteString(w, r.URL.Query().Get("name"))
 }
 
-func trafficHandlerTest(w traffic.ResponseWriter, r *traffic.Request) {
-	io.WriteString(w, r.RequestURI)
+func trafficHandlerTest(rt route) traffic.HttpHandleFunc {
+	return func(w traffic.ResponseWriter, r *traffic.Request) {
+		writeTest(w, r.Request, rt, r.RequestURI, r.URL.Query().Get)
+	}
 }
 
 func initTraffic() {
 	traffic.SetVar("env", "bench")
 }
 
-func loadTraffic(routes []route) http.Handler {
+func loadTraffic(routes []route, mode handlerMode) http.Handler {
 	h := trafficHandler
-	if loadTestHandler {
-		h = trafficHandlerTest
+	if mode == writeHandler {
+		h = trafficHandlerWrite
 	}
 
 	router := traffic.New()
 	for _, route := range routes {
+		if mode == testHandler {
+			h = trafficHandlerTest(route)
+		}
+		path := catchAllParam.ReplaceAllString(route.path, "/:$1*")
 		switch route.method {
 		case "GET":
-			router.Get(route.path, h)
+			router.Get(path, h)
 		case "POST":
-			router.Post(route.path, h)
+			router.Post(path, h)
 		case "PUT":
-			router.Put(route.path, h)
+			router.Put(path, h)
 		case "PATCH":
-			router.Patch(route.path, h)
+			router.Patch(path, h)
 		case "DELETE":
-			router.Delete(route.path, h)
+			router.Delete(path, h)
 		default:
 			panic("Unknow HTTP method: " + route.method)
 		}
@@ -1556,25 +1842,6 @@ func loadTraffic(routes []route) http.Handler {
 	return router
 }
 
-func loadTrafficSingle(method, path string, handler traffic.HttpHandleFunc) http.Handler {
-	router := traffic.New()
-	switch method {
-	case "GET":
-		router.Get(path, handler)
-	case "POST":
-		router.Post(path, handler)
-	case "PUT":
-		router.Put(path, handler)
-	case "PATCH":
-		router.Patch(path, handler)
-	case "DELETE":
-		router.Delete(path, handler)
-	default:
-		panic("Unknow HTTP method: " + method)
-	}
-	return router
-}
-
 // Mailgun Vulcan
 func vulcanHandler(w http.ResponseWriter, r *http.Request) {}
 
@@ -1582,16 +1849,26 @@ func vulcanHandlerWrite(w http.ResponseWriter, r *http.Request) {
 	io.WriteString(w, r.URL.Query().Get("name"))
 }
 
-func loadVulcan(routes []route) http.Handler {
-	h := httpHandlerFunc
-	if loadTestHandler {
-		h = httpHandlerFuncTest
+func loadVulcan(routes []route, mode handlerMode) http.Handler {
+	h := vulcanHandler
+	if mode == writeHandler {
+		h = vulcanHandlerWrite
+	}
+
+	// only unsigned integers can be expressed, see vulcanConstraint
+	param := func(name, re string) string {
+		if re == "" {
+			return "<" + name + ">"
+		}
+		return "<int:" + name + ">"
 	}
 
-	re := regexp.MustCompile(":([^/]*)")
 	mux := vulcan.NewMux()
 	for _, route := range routes {
-		path := re.ReplaceAllString(route.path, "<$1>")
+		if mode == testHandler {
+			h = httpHandlerFuncTest(route)
+		}
+		path := translateParams(route.path, param)
 		expr := fmt.Sprintf(`Method("%s") && Path("%s")`, route.method, path)
 		if err := mux.HandleFunc(expr, h); err != nil {
 			panic(err)
@@ -1600,15 +1877,10 @@ func loadVulcan(routes []route) http.Handler {
 	return mux
 }
 
-func loadVulcanSingle(method, path string, handler http.HandlerFunc) http.Handler {
-	re := regexp.MustCompile(":([^/]*)")
-	mux := vulcan.NewMux()
-	path = re.ReplaceAllString(path, "<$1>")
-	expr := fmt.Sprintf(`Method("%s") && Path("%s")`, method, path)
-	if err := mux.HandleFunc(expr, httpHandlerFunc); err != nil {
-		panic(err)
-	}
-	return mux
+// vulcanConstraint reports whether the constraint is the one of <int:name>
+// parameters, the only ones Vulcan supports besides unconstrained ones.
+func vulcanConstraint(re string) bool {
+	return re == "[0-9]+"
 }
 
 // Zeus
@@ -1616,14 +1888,17 @@ func loadVulcanSingle(method, path string, handler http.HandlerFunc) http.Handle
 // 	io.WriteString(w, zeus.Var(r, "name"))
 // }
 
-// func loadZeus(routes []route) http.Handler {
+// func loadZeus(routes []route, mode handlerMode) http.Handler {
 // 	h := http.HandlerFunc(httpHandlerFunc)
-// 	if loadTestHandler {
-// 		h = http.HandlerFunc(httpHandlerFuncTest)
+// 	if mode == writeHandler {
+// 		h = http.HandlerFunc(zeusHandlerWrite)
 // 	}
 
 // 	m := zeus.New()
 // 	for _, route := range routes {
+// 		if mode == testHandler {
+// 			h = httpHandlerFuncTest(route)
+// 		}
 // 		switch route.method {
 // 		case "GET":
 // 			m.GET(route.path, h)
@@ -1640,23 +1915,6 @@ func loadVulcanSingle(method, path string, handler http.HandlerFunc) http.Handle
 // 	return m
 // }
 
-// func loadZeusSingle(method, path string, handler http.HandlerFunc) http.Handler {
-// 	m := zeus.New()
-// 	switch method {
-// 	case "GET":
-// 		m.GET(path, handler)
-// 	case "POST":
-// 		m.POST(path, handler)
-// 	case "PUT":
-// 		m.PUT(path, handler)
-// 	case "DELETE":
-// 		m.DELETE(path, handler)
-// 	default:
-// 		panic("Unknow HTTP method: " + method)
-// 	}
-// 	return m
-// }
-
 // Usage notice
 func main() {
 	fmt.Println("Usage: go test -bench=. -timeout=20m")
@@ -1,91 +1,362 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"strings"
 	"testing"
 )
 
-var (
-	// load functions of all routers
-	routers = []struct {
-		name string
-		load func(routes []route) http.Handler
-	}{
-		{"Ace", loadAce},
-		{"Aero", loadAero},
-		{"Bear", loadBear},
-		{"Beego", loadBeego},
-		{"Bone", loadBone},
-		{"Chi", loadChi},
-		{"CloudyKitRouter", loadCloudyKitRouter},
-		{"Denco", loadDenco},
-		{"Echo", loadEcho},
-		{"Gin", loadGin},
-		{"GocraftWeb", loadGocraftWeb},
-		{"Goji", loadGoji},
-		{"Gojiv2", loadGojiv2},
-		{"GoJsonRest", loadGoJsonRest},
-		{"GoRestful", loadGoRestful},
-		{"GorillaMux", loadGorillaMux},
-		{"GowwwRouter", loadGowwwRouter},
-		{"HttpRouter", loadHttpRouter},
-		{"HttpTreeMux", loadHttpTreeMux},
-		//{"Kocha", loadKocha},
-		{"LARS", loadLARS},
-		{"Macaron", loadMacaron},
-		{"Martini", loadMartini},
-		{"Pat", loadPat},
-		{"Possum", loadPossum},
-		{"R2router", loadR2router},
-		// {"Revel", loadRevel},
-		{"Rivet", loadRivet},
-		//{"Tango", loadTango},
-		{"TigerTonic", loadTigerTonic},
-		{"Traffic", loadTraffic},
-		{"Vulcan", loadVulcan},
-		// {"Zeus", loadZeus},
-	}
+// api is a set of routes the routers are tested and benchmarked with.
+type api struct {
+	name   string
+	routes []route
+
+	// paths are the request paths of the routes, with concrete values for
+	// the parameters
+	paths []string
+
+	// misses are near-miss paths which no route matches, by miss class
+	misses [][]string
+
+	// wrongMethods are requests for known paths with a method which no
+	// route matching the path has
+	wrongMethods []route
+
+	// heads are HEAD requests for the GET routes, options are OPTIONS
+	// requests for every path
+	heads   []route
+	options []route
+
+	// variants are requests for unclean variants of the paths, by variant
+	// class
+	variants [][]pathVariant
+
+	// catchAlls are requests for the routes with catch-all parameters, by
+	// catch-all class
+	catchAlls [][]route
+
+	// constrained are requests for the routes with constrained parameters,
+	// by constraint class
+	constrained [][]route
+
+	// encoded are requests for paths with a percent-encoded segment, by
+	// encoding class
+	encoded [][]encodedRequest
+
+	// Requests made by the single request benchmarks. If empty, they are
+	// derived from the routes.
+	static    string
+	param     string
+	twoParams string
+
+	// weights are the weights of the routes in the Weighted traffic
+	// distribution, if any
+	weights []float64
 
+	// replays are the access logs replayed against the API
+	replays []*replay
+
+	// handlers are the routers loaded so far, by router name
+	handlers map[string]http.Handler
+
+	// conflicts are the errors of the routers which fail to load the routes
+	// of an added API, by router name
+	conflicts map[string]error
+}
+
+// supportedBy reports whether the router is able to serve all routes of the
+// API.
+func (a *api) supportedBy(router *routerAdapter) bool {
+	return router.supports(a.routes) && a.conflicts[router.name] == nil
+}
+
+var (
 	// all APIs
-	apis = []struct {
-		name   string
-		routes []route
-	}{
-		{"GitHub", githubAPI},
-		{"GPlus", gplusAPI},
-		{"Parse", parseAPI},
-		{"Static", staticRoutes},
+	apis = []*api{
+		{
+			name:   "GitHub",
+			routes: githubAPI,
+			static: "/user/repos",
+			param:  "/repos/julienschmidt/httprouter/stargazers",
+		},
+		{
+			name:      "GPlus",
+			routes:    gplusAPI,
+			static:    "/people",
+			param:     "/people/118051310819094153327",
+			twoParams: "/people/118051310819094153327/activities/123456789",
+		},
+		{
+			name:      "Parse",
+			routes:    parseAPI,
+			static:    "/1/users",
+			param:     "/1/classes/go",
+			twoParams: "/1/classes/go/123456789",
+		},
+		{
+			name:   "Static",
+			routes: staticRoutes,
+		},
+		{
+			name:   "Wildcard",
+			routes: wildcardAPI,
+		},
+		{
+			name:   "Constrained",
+			routes: constrainedAPI,
+		},
+		{
+			name: "Synthetic",
+			routes: routeGen{
+				depth:         4,
+				fanOut:        4,
+				paramFraction: 0.3,
+				sharedPrefix:  0.3,
+				seed:          1,
+			}.routes(),
+		},
 	}
 )
 
-func TestRouters(t *testing.T) {
-	loadTestHandler = true
+// routeMethods are the methods every router can register routes for.
+var routeMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+// standardMethods are the methods defined by net/http.
+var standardMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace,
+}
+
+// addAPI adds an API which is not known at compile time.
+func addAPI(a *api) error {
+	if len(a.routes) == 0 {
+		return fmt.Errorf("API %s has no routes", a.name)
+	}
+	for _, other := range apis {
+		if other.name == a.name {
+			return fmt.Errorf("API %s already exists", a.name)
+		}
+	}
 
+	// the routes of added APIs may conflict for some routers, which panic
 	for _, router := range routers {
+		if !a.supportedBy(router) {
+			continue
+		}
+		if _, err := router.tryBuild(a.routes, noopHandler); err != nil {
+			if a.conflicts == nil {
+				a.conflicts = make(map[string]error)
+			}
+			a.conflicts[router.name] = err
+			fmt.Fprintf(os.Stderr, "API %s: %s fails to load the routes: %v\n", a.name, router.name, err)
+		}
+	}
+	apis = append(apis, a)
+	return nil
+}
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if err := importOpenAPIFiles(); err != nil {
+		fmt.Fprintln(os.Stderr, "importing OpenAPI documents:", err)
+		os.Exit(2)
+	}
+	if err := loadRouteFiles(); err != nil {
+		fmt.Fprintln(os.Stderr, "loading route files:", err)
+		os.Exit(2)
+	}
+	if err := loadReplays(); err != nil {
+		fmt.Fprintln(os.Stderr, "loading access logs:", err)
+		os.Exit(2)
+	}
+	for _, api := range apis {
+		api.generateRequests()
+	}
+
+	code := m.Run()
+
+	if err := writeMemReport(); err != nil {
+		fmt.Fprintln(os.Stderr, "writing memory report:", err)
+		if code == 0 {
+			code = 1
+		}
+	}
+	if err := writeScaleReport(); err != nil {
+		fmt.Fprintln(os.Stderr, "writing scaling report:", err)
+		if code == 0 {
+			code = 1
+		}
+	}
+
+	for _, router := range routers {
+		if router.used && router.teardown != nil {
+			router.teardown()
+		}
+	}
+
+	os.Exit(code)
+}
+
+func TestAddAPI(t *testing.T) {
+	defer func(all []*api) { apis = all }(apis)
+
+	// a static and a parameter segment at the same position
+	a := &api{name: "Conflicting", routes: []route{{"GET", "/pets/mine"}, {"GET", "/pets/:id"}}}
+	if err := addAPI(a); err != nil {
+		t.Fatal(err)
+	}
+	if a.supportedBy(routerByName("HttpRouter")) {
+		t.Error("HttpRouter panics for the routes, but supports the API")
+	}
+	if !a.supportedBy(routerByName("Chi")) {
+		t.Error("Chi loads the routes, but does not support the API")
+	}
+
+	if err := addAPI(&api{name: "Conflicting", routes: a.routes}); err == nil {
+		t.Error("expected an error for an API which already exists")
+	}
+}
+
+func TestRouters(t *testing.T) {
+	for _, router := range routers {
+		if router.skipTest {
+			continue
+		}
+
 		req, _ := http.NewRequest("GET", "/", nil)
 		u := req.URL
 		rq := u.RawQuery
 
 		for _, api := range apis {
-			r := router.load(api.routes)
+			if !api.supportedBy(router) {
+				continue
+			}
+			r := router.build(api.routes, testHandler)
+
+			skipped, unsupported := 0, 0
+			for i, route := range api.routes {
+				path := api.paths[i]
+				if !router.handles(route.method) {
+					unsupported++
+					continue
+				}
+				if router.paramDelims != "" && !canMatch(route.path, path, router.paramDelims) {
+					skipped++
+					continue
+				}
+
+				// the route generated the path, but a more specific one
+				// may match it as well
+				expected, ok := oracleMatch(api.routes, route.method, path)
+				if !ok {
+					t.Fatalf("API %s: %s %s is not matched by its route %s", api.name, route.method, path, route.path)
+				}
+				names, want := expectedParams(expected.path, path)
+				if router.anonymousParams {
+					names, want = nil, ""
+				}
 
-			for _, route := range api.routes {
 				w := httptest.NewRecorder()
 				req.Method = route.method
-				req.RequestURI = route.path
-				u.Path = route.path
+				req.RequestURI = path
+				req.Header.Set(paramsHeader, strings.Join(names, ","))
+				u.Path = path
 				u.RawQuery = rq
 				r.ServeHTTP(w, req)
-				if w.Code != 200 || w.Body.String() != route.path {
+				body := w.Body.String()
+				switch {
+				case w.Code != 200 || !strings.HasPrefix(body, path) ||
+					(len(body) > len(path) && body[len(path)] != '\n'):
 					t.Errorf(
-						"%s in API %s: %d - %s; expected %s %s\n",
-						router.name, api.name, w.Code, w.Body.String(), route.method, route.path,
+						"%s in API %s: %d - %s; expected %s %s for route %s\n",
+						router.name, api.name, w.Code, body, route.method, path, expected.path,
+					)
+				case w.Header().Get(routeHeader) != expected.method+" "+expected.path:
+					t.Errorf(
+						"%s in API %s: %s %s matched route %q; expected %s %s\n",
+						router.name, api.name, route.method, path, w.Header().Get(routeHeader),
+						expected.method, expected.path,
+					)
+				case body[len(path):] != want:
+					t.Errorf(
+						"%s in API %s: %s %s for route %s has parameters %q; expected %q\n",
+						router.name, api.name, route.method, path, expected.path,
+						body[len(path):], want,
 					)
 				}
 			}
+			if skipped > 0 {
+				t.Logf("%s in API %s: skipped %d routes with parameter values containing %q",
+					router.name, api.name, skipped, router.paramDelims)
+			}
+			if unsupported > 0 {
+				t.Logf("%s in API %s: skipped %d routes with unsupported methods",
+					router.name, api.name, unsupported)
+			}
+		}
+	}
+}
+
+// TestMicroBenchmarks loads every router with the route of each micro
+// benchmark it supports and makes the benchmark's request once, in the mode
+// of the benchmark and with the test handler, which must serve it.
+func TestMicroBenchmarks(t *testing.T) {
+	for _, router := range routers {
+		for _, bm := range microBenchmarks {
+			if !router.supports([]route{{"GET", bm.path}}) {
+				continue
+			}
+
+			h := router.loadSingle("GET", bm.path, bm.mode)
+			r, _ := http.NewRequest("GET", bm.request, nil)
+			r.RequestURI = bm.request
+			if serveRecover(h, httptest.NewRecorder(), r) {
+				t.Errorf("%s: %s panics for GET %s", router.name, bm.name, bm.request)
+				continue
+			}
+			if router.skipTest {
+				continue
+			}
+
+			h = router.loadSingle("GET", bm.path, testHandler)
+			w := httptest.NewRecorder()
+			r, _ = http.NewRequest("GET", bm.request, nil)
+			r.RequestURI = bm.request
+			h.ServeHTTP(w, r)
+			if w.Code != 200 || w.Header().Get(routeHeader) != "GET "+bm.path {
+				t.Errorf("%s: %s answers GET %s with %d from route %q; expected GET %s",
+					router.name, bm.name, bm.request, w.Code, w.Header().Get(routeHeader), bm.path)
+			}
 		}
 	}
+}
 
-	loadTestHandler = false
+// expectedParams returns the names of the parameters of the route and the
+// name=value lines a test handler writes for them after the request URI.
+// Catch-all parameters are left out, since many routers only support them
+// without a name or under a name of their own.
+func expectedParams(route, path string) (names []string, lines string) {
+	values := pathParams(route, path)
+	for _, m := range paramPattern.FindAllStringSubmatch(route, -1) {
+		names = append(names, m[1])
+		lines += "\n" + m[1] + "=" + values[m[1]]
+	}
+	return names, lines
+}
+
+// canMatch reports whether none of the parameter values of the request path
+// contain any of the delimiters. Catch-all parameters are not affected by
+// them.
+func canMatch(route, path, delims string) bool {
+	segments := strings.Split(path, "/")
+	for i, s := range strings.Split(route, "/") {
+		if strings.HasPrefix(s, ":") && i < len(segments) && strings.ContainsAny(segments[i], delims) {
+			return false
+		}
+	}
+	return true
 }
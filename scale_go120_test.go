@@ -0,0 +1,11 @@
+//go:build go1.20
+// +build go1.20
+
+package main
+
+import "testing"
+
+// lookupNs returns the ns/op measured by the benchmark so far.
+func lookupNs(b *testing.B) int64 {
+	return b.Elapsed().Nanoseconds() / int64(b.N)
+}
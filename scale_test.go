@@ -0,0 +1,254 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+var (
+	scale         = flag.Bool("scale", false, "run BenchmarkScaling")
+	scaleJSON     = flag.String("scale.json", "", "write the scaling report as JSON to `file`")
+	scaleMarkdown = flag.String("scale.md", "", "write the scaling report as markdown tables to `file`")
+)
+
+// scaleSizes are the numbers of routes the routers are loaded with by
+// BenchmarkScaling.
+var scaleSizes = []int{10, 100, 1000, 10000, 50000}
+
+// scaleStat is the cost of a router loaded with a number of routes.
+type scaleStat struct {
+	Router string `json:"router"`
+	Routes int    `json:"routes"`
+
+	Bytes    int64 `json:"bytes"`
+	Garbage  int64 `json:"garbage"`
+	BuildNs  int64 `json:"build_ns"`
+	LookupNs int64 `json:"lookup_ns"`
+}
+
+// scaleReport collects the results of BenchmarkScaling.
+var scaleReport []scaleStat
+
+// scaleRoutes returns n distinct routes shaped like a REST API. Every
+// resource has a list, an item and a nested list route and resources are
+// grouped in batches of 50.
+func scaleRoutes(n int) []route {
+	methods := []string{"GET", "POST", "PUT", "DELETE"}
+	routes := make([]route, 0, n)
+	for i := 0; len(routes) < n; i++ {
+		resource := "/group" + strconv.Itoa(i/50) + "/resource" + strconv.Itoa(i%50)
+		for _, path := range []string{resource, resource + "/:id", resource + "/:id/items"} {
+			if len(routes) == n {
+				break
+			}
+			routes = append(routes, route{methods[i%len(methods)], path})
+		}
+	}
+	return routes
+}
+
+// BenchmarkScaling loads every router with a growing number of routes and
+// measures the construction memory and time and the lookup cost at each
+// size, e.g. BenchmarkScaling/HttpRouter/10000. It only runs if the -scale
+// flag is set.
+func BenchmarkScaling(b *testing.B) {
+	if !*scale {
+		b.Skip("scaling benchmarks are disabled, use -scale to enable them")
+	}
+
+	for _, router := range routers {
+		router := router
+		if router.staticOnly {
+			continue
+		}
+		b.Run(router.name, func(b *testing.B) {
+			for _, n := range scaleSizes {
+				routes := scaleRoutes(n)
+				b.Run(strconv.Itoa(n), func(b *testing.B) {
+					benchScaling(b, router, routes)
+				})
+			}
+		})
+	}
+}
+
+// scaleLookups is the maximum number of different routes requested.
+const scaleLookups = 100
+
+// scaleLoaded is the router last loaded by benchScaling. The benchmark
+// function is run multiple times with growing b.N, but the router is only
+// loaded and measured once.
+var scaleLoaded struct {
+	router string
+	routes int
+	mem    memStat
+	h      http.Handler
+}
+
+func benchScaling(b *testing.B, router *routerAdapter, routes []route) {
+	if scaleLoaded.router != router.name || scaleLoaded.routes != len(routes) {
+		scaleLoaded.h = nil // release the previous router before measuring
+		scaleLoaded.mem, scaleLoaded.h = measureMem(func() http.Handler {
+			return router.build(routes, noopHandler)
+		})
+		scaleLoaded.router = router.name
+		scaleLoaded.routes = len(routes)
+	}
+	mem, h := scaleLoaded.mem, scaleLoaded.h
+
+	// request routes spread over the whole table
+	step := len(routes)/scaleLookups + 1
+	rnd := rand.New(rand.NewSource(*paramSeed))
+	var lookups []route
+	for i := 0; i < len(routes); i += step {
+		lookups = append(lookups, route{routes[i].method, concretePath(routes[i].path, rnd)})
+	}
+	w := new(mockResponseWriter)
+	r, _ := http.NewRequest("GET", "/", nil)
+	u := r.URL
+	rq := u.RawQuery
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i, j := 0, 0; i < b.N; i++ {
+		r.Method = lookups[j].method
+		r.RequestURI = lookups[j].path
+		u.Path = lookups[j].path
+		u.RawQuery = rq
+		h.ServeHTTP(w, r)
+
+		if j++; j == len(lookups) {
+			j = 0
+		}
+	}
+
+	b.StopTimer()
+	b.ReportMetric(float64(mem.Bytes), "B-retained")
+	b.ReportMetric(float64(mem.BuildNs), "ns-build")
+
+	// only the last run of the benchmark function is kept
+	stat := scaleStat{
+		Router:   router.name,
+		Routes:   len(routes),
+		Bytes:    mem.Bytes,
+		Garbage:  mem.Garbage,
+		BuildNs:  mem.BuildNs,
+		LookupNs: lookupNs(b),
+	}
+	if n := len(scaleReport); n > 0 && scaleReport[n-1].Router == stat.Router &&
+		scaleReport[n-1].Routes == stat.Routes {
+		scaleReport[n-1] = stat
+	} else {
+		scaleReport = append(scaleReport, stat)
+	}
+}
+
+// writeScaleReport writes the scaling report to the files given by the
+// -scale.json and -scale.md flags. If neither is set, the markdown tables
+// are printed to stdout.
+func writeScaleReport() error {
+	if len(scaleReport) == 0 {
+		return nil
+	}
+
+	if *scaleJSON == "" && *scaleMarkdown == "" {
+		fmt.Println()
+		return writeScaleMarkdown(os.Stdout, scaleReport)
+	}
+	if *scaleJSON != "" {
+		err := writeFile(*scaleJSON, func(w io.Writer) error {
+			return writeJSON(w, scaleReport)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if *scaleMarkdown != "" {
+		err := writeFile(*scaleMarkdown, func(w io.Writer) error {
+			return writeScaleMarkdown(w, scaleReport)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeScaleMarkdown writes one table for each of the retained memory, the
+// construction time and the lookup time, with a row per router showing its
+// curve over the number of routes.
+func writeScaleMarkdown(w io.Writer, stats []scaleStat) error {
+	tables := []struct {
+		title string
+		value func(s scaleStat) string
+	}{
+		{"Retained memory", func(s scaleStat) string { return strconv.FormatInt(s.Bytes, 10) + " B" }},
+		{"Construction time", func(s scaleStat) string { return time.Duration(s.BuildNs).String() }},
+		{"Lookup time", func(s scaleStat) string {
+			if s.LookupNs == 0 {
+				return "-"
+			}
+			return strconv.FormatInt(s.LookupNs, 10) + " ns/op"
+		}},
+	}
+
+	var routerNames []string
+	var sizes []int
+	cells := make(map[string]map[int]scaleStat)
+	for _, s := range stats {
+		if cells[s.Router] == nil {
+			cells[s.Router] = make(map[int]scaleStat)
+			routerNames = append(routerNames, s.Router)
+		}
+		if !containsInt(sizes, s.Routes) {
+			sizes = append(sizes, s.Routes)
+		}
+		cells[s.Router][s.Routes] = s
+	}
+
+	header := []string{"Router"}
+	for _, n := range sizes {
+		header = append(header, strconv.Itoa(n)+" routes")
+	}
+
+	for i, table := range tables {
+		if i > 0 {
+			io.WriteString(w, "\n")
+		}
+		if _, err := io.WriteString(w, "#### "+table.title+"\n\n"); err != nil {
+			return err
+		}
+		rows := make([][]string, len(routerNames))
+		for j, router := range routerNames {
+			rows[j] = []string{router}
+			for _, n := range sizes {
+				cell := "-"
+				if s, ok := cells[router][n]; ok {
+					cell = table.value(s)
+				}
+				rows[j] = append(rows[j], cell)
+			}
+		}
+		if err := writeMarkdownTable(w, header, rows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func containsInt(list []int, n int) bool {
+	for _, v := range list {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
@@ -4,11 +4,6 @@
 
 package main
 
-import (
-	"net/http"
-	"testing"
-)
-
 var staticRoutes = []route{
 	{"GET", "/"},
 	{"GET", "/cmd.html"},
@@ -168,262 +163,3 @@ var staticRoutes = []route{
 	{"GET", "/progs/timeout2.go"},
 	{"GET", "/progs/update.bash"},
 }
-
-var (
-	staticHttpServeMux http.Handler
-
-	staticAce             http.Handler
-	staticAero            http.Handler
-	staticBear            http.Handler
-	staticBeego           http.Handler
-	staticBone            http.Handler
-	staticChi             http.Handler
-	staticCloudyKitRouter http.Handler
-	staticDenco           http.Handler
-	staticEcho            http.Handler
-	staticGin             http.Handler
-	staticGocraftWeb      http.Handler
-	staticGoji            http.Handler
-	staticGojiv2          http.Handler
-	staticGoJsonRest      http.Handler
-	staticGoRestful       http.Handler
-	staticGorillaMux      http.Handler
-	staticGowwwRouter     http.Handler
-	staticHttpRouter      http.Handler
-	staticHttpTreeMux     http.Handler
-	staticKocha           http.Handler
-	staticLARS            http.Handler
-	staticMacaron         http.Handler
-	staticMartini         http.Handler
-	staticPat             http.Handler
-	staticPossum          http.Handler
-	staticR2router        http.Handler
-	// staticRevel           http.Handler
-	staticRivet      http.Handler
-	staticTango      http.Handler
-	staticTigerTonic http.Handler
-	staticTraffic    http.Handler
-	staticVulcan     http.Handler
-	// staticZeus        http.Handler
-)
-
-func init() {
-	println("#Static Routes:", len(staticRoutes))
-
-	calcMem("HttpServeMux", func() {
-		serveMux := http.NewServeMux()
-		for _, route := range staticRoutes {
-			serveMux.HandleFunc(route.path, httpHandlerFunc)
-		}
-		staticHttpServeMux = serveMux
-	})
-
-	calcMem("Ace", func() {
-		staticAce = loadAce(staticRoutes)
-	})
-	calcMem("Aero", func() {
-		staticAero = loadAero(staticRoutes)
-	})
-	calcMem("Bear", func() {
-		staticBear = loadBear(staticRoutes)
-	})
-	calcMem("Beego", func() {
-		staticBeego = loadBeego(staticRoutes)
-	})
-	calcMem("Bone", func() {
-		staticBone = loadBone(staticRoutes)
-	})
-	calcMem("Chi", func() {
-		staticChi = loadChi(staticRoutes)
-	})
-	calcMem("CloudyKitRouter", func() {
-		staticCloudyKitRouter = loadCloudyKitRouter(staticRoutes)
-	})
-	calcMem("Denco", func() {
-		staticDenco = loadDenco(staticRoutes)
-	})
-	calcMem("Echo", func() {
-		staticEcho = loadEcho(staticRoutes)
-	})
-	calcMem("Gin", func() {
-		staticGin = loadGin(staticRoutes)
-	})
-	calcMem("GocraftWeb", func() {
-		staticGocraftWeb = loadGocraftWeb(staticRoutes)
-	})
-	calcMem("Goji", func() {
-		staticGoji = loadGoji(staticRoutes)
-	})
-	calcMem("Gojiv2", func() {
-		staticGojiv2 = loadGojiv2(staticRoutes)
-	})
-	calcMem("GoJsonRest", func() {
-		staticGoJsonRest = loadGoJsonRest(staticRoutes)
-	})
-	calcMem("GoRestful", func() {
-		staticGoRestful = loadGoRestful(staticRoutes)
-	})
-	calcMem("GorillaMux", func() {
-		staticGorillaMux = loadGorillaMux(staticRoutes)
-	})
-	calcMem("GowwwRouter", func() {
-		staticGowwwRouter = loadGowwwRouter(staticRoutes)
-	})
-	calcMem("HttpRouter", func() {
-		staticHttpRouter = loadHttpRouter(staticRoutes)
-	})
-	calcMem("HttpTreeMux", func() {
-		staticHttpTreeMux = loadHttpTreeMux(staticRoutes)
-	})
-	calcMem("Kocha", func() {
-		staticKocha = loadKocha(staticRoutes)
-	})
-	calcMem("LARS", func() {
-		staticLARS = loadLARS(staticRoutes)
-	})
-	calcMem("Macaron", func() {
-		staticMacaron = loadMacaron(staticRoutes)
-	})
-	calcMem("Martini", func() {
-		staticMartini = loadMartini(staticRoutes)
-	})
-	calcMem("Pat", func() {
-		staticPat = loadPat(staticRoutes)
-	})
-	calcMem("Possum", func() {
-		staticPossum = loadPossum(staticRoutes)
-	})
-	calcMem("R2router", func() {
-		staticR2router = loadR2router(staticRoutes)
-	})
-	// calcMem("Revel", func() {
-	// 	staticRevel = loadRevel(staticRoutes)
-	// })
-	calcMem("Rivet", func() {
-		staticRivet = loadRivet(staticRoutes)
-	})
-	calcMem("Tango", func() {
-		staticTango = loadTango(staticRoutes)
-	})
-	calcMem("TigerTonic", func() {
-		staticTigerTonic = loadTigerTonic(staticRoutes)
-	})
-	calcMem("Traffic", func() {
-		staticTraffic = loadTraffic(staticRoutes)
-	})
-	calcMem("Vulcan", func() {
-		staticVulcan = loadVulcan(staticRoutes)
-	})
-	// calcMem("Zeus", func() {
-	// 	staticZeus = loadZeus(staticRoutes)
-	// })
-
-	println()
-}
-
-// All routes
-
-func BenchmarkAce_StaticAll(b *testing.B) {
-	benchRoutes(b, staticAce, staticRoutes)
-}
-func BenchmarkAero_StaticAll(b *testing.B) {
-	benchRoutes(b, staticAero, staticRoutes)
-}
-func BenchmarkHttpServeMux_StaticAll(b *testing.B) {
-	benchRoutes(b, staticHttpServeMux, staticRoutes)
-}
-func BenchmarkBeego_StaticAll(b *testing.B) {
-	benchRoutes(b, staticBeego, staticRoutes)
-}
-func BenchmarkBear_StaticAll(b *testing.B) {
-	benchRoutes(b, staticBear, staticRoutes)
-}
-func BenchmarkBone_StaticAll(b *testing.B) {
-	benchRoutes(b, staticBone, staticRoutes)
-}
-func BenchmarkChi_StaticAll(b *testing.B) {
-	benchRoutes(b, staticChi, staticRoutes)
-}
-func BenchmarkCloudyKitRouter_StaticAll(b *testing.B) {
-	benchRoutes(b, staticCloudyKitRouter, staticRoutes)
-}
-func BenchmarkDenco_StaticAll(b *testing.B) {
-	benchRoutes(b, staticDenco, staticRoutes)
-}
-func BenchmarkEcho_StaticAll(b *testing.B) {
-	benchRoutes(b, staticEcho, staticRoutes)
-}
-func BenchmarkGin_StaticAll(b *testing.B) {
-	benchRoutes(b, staticGin, staticRoutes)
-}
-func BenchmarkGocraftWeb_StaticAll(b *testing.B) {
-	benchRoutes(b, staticGocraftWeb, staticRoutes)
-}
-func BenchmarkGoji_StaticAll(b *testing.B) {
-	benchRoutes(b, staticGoji, staticRoutes)
-}
-func BenchmarkGojiv2_StaticAll(b *testing.B) {
-	benchRoutes(b, staticGojiv2, staticRoutes)
-}
-func BenchmarkGoJsonRest_StaticAll(b *testing.B) {
-	benchRoutes(b, staticGoJsonRest, staticRoutes)
-}
-func BenchmarkGoRestful_StaticAll(b *testing.B) {
-	benchRoutes(b, staticGoRestful, staticRoutes)
-}
-func BenchmarkGorillaMux_StaticAll(b *testing.B) {
-	benchRoutes(b, staticGorillaMux, staticRoutes)
-}
-func BenchmarkGowwwRouter_StaticAll(b *testing.B) {
-	benchRoutes(b, staticGowwwRouter, staticRoutes)
-}
-func BenchmarkHttpRouter_StaticAll(b *testing.B) {
-	benchRoutes(b, staticHttpRouter, staticRoutes)
-}
-func BenchmarkHttpTreeMux_StaticAll(b *testing.B) {
-	benchRoutes(b, staticHttpRouter, staticRoutes)
-}
-func BenchmarkKocha_StaticAll(b *testing.B) {
-	benchRoutes(b, staticKocha, staticRoutes)
-}
-func BenchmarkLARS_StaticAll(b *testing.B) {
-	benchRoutes(b, staticLARS, staticRoutes)
-}
-func BenchmarkMacaron_StaticAll(b *testing.B) {
-	benchRoutes(b, staticMacaron, staticRoutes)
-}
-func BenchmarkMartini_StaticAll(b *testing.B) {
-	benchRoutes(b, staticMartini, staticRoutes)
-}
-func BenchmarkPat_StaticAll(b *testing.B) {
-	benchRoutes(b, staticPat, staticRoutes)
-}
-func BenchmarkPossum_StaticAll(b *testing.B) {
-	benchRoutes(b, staticPossum, staticRoutes)
-}
-func BenchmarkR2router_StaticAll(b *testing.B) {
-	benchRoutes(b, staticR2router, staticRoutes)
-}
-
-// func BenchmarkRevel_StaticAll(b *testing.B) {
-// 	benchRoutes(b, staticRevel, staticRoutes)
-// }
-func BenchmarkRivet_StaticAll(b *testing.B) {
-	benchRoutes(b, staticRivet, staticRoutes)
-}
-func BenchmarkTango_StaticAll(b *testing.B) {
-	benchRoutes(b, staticTango, staticRoutes)
-}
-func BenchmarkTigerTonic_StaticAll(b *testing.B) {
-	benchRoutes(b, staticTigerTonic, staticRoutes)
-}
-func BenchmarkTraffic_StaticAll(b *testing.B) {
-	benchRoutes(b, staticTraffic, staticRoutes)
-}
-func BenchmarkVulcan_StaticAll(b *testing.B) {
-	benchRoutes(b, staticVulcan, staticRoutes)
-}
-
-// func BenchmarkZeus_StaticAll(b *testing.B) {
-// 	benchRoutes(b, staticZeus, staticRoutes)
-// }
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Routes with catch-all parameters, which match the rest of the path. The
+// API is only loaded into routers supporting them and reported as
+// unsupported for all others.
+var wildcardAPI = []route{
+	// Static files
+	{"GET", "/"},
+	{"GET", "/favicon.ico"},
+	{"GET", "/static/*filepath"},
+	{"GET", "/assets/*filepath"},
+
+	// Git references and repository contents of the GitHub API
+	{"GET", "/repos/:owner/:repo"},
+	{"GET", "/repos/:owner/:repo/git/refs"},
+	{"POST", "/repos/:owner/:repo/git/refs"},
+	{"GET", "/repos/:owner/:repo/git/refs/*ref"},
+	{"PATCH", "/repos/:owner/:repo/git/refs/*ref"},
+	{"DELETE", "/repos/:owner/:repo/git/refs/*ref"},
+	{"GET", "/repos/:owner/:repo/readme"},
+	{"GET", "/repos/:owner/:repo/contents/*path"},
+	{"PUT", "/repos/:owner/:repo/contents/*path"},
+	{"DELETE", "/repos/:owner/:repo/contents/*path"},
+
+	// User files
+	{"GET", "/users/:user"},
+	{"GET", "/users/:user/files/*filepath"},
+	{"PUT", "/users/:user/files/*filepath"},
+}
+
+// catchAllClasses generate request paths for routes with a catch-all
+// parameter. A class returns an empty string for routes it does not apply
+// to.
+var catchAllClasses = []struct {
+	name string
+	path func(route string, rnd *rand.Rand) string
+}{
+	// The catch-all follows a static prefix, e.g. /static/*filepath.
+	{"StaticPrefix", func(route string, rnd *rand.Rand) string {
+		i := strings.Index(route, "/*") + 1
+		if i == 0 || strings.Contains(route[:i], ":") {
+			return ""
+		}
+		return concretePath(route, rnd)
+	}},
+	// The catch-all follows parameters, e.g. /repos/:owner/:repo/contents/*path.
+	{"AfterParams", func(route string, rnd *rand.Rand) string {
+		i := strings.Index(route, "/*") + 1
+		if i == 0 || !strings.Contains(route[:i], ":") {
+			return ""
+		}
+		return concretePath(route, rnd)
+	}},
+	// The catch-all matches a file in 8 to 16 nested directories.
+	{"Deep", func(route string, rnd *rand.Rand) string {
+		i := strings.Index(route, "/*") + 1
+		if i == 0 {
+			return ""
+		}
+		return concretePath(route[:i], rnd) + filePath(rnd, 8+rnd.Intn(9))
+	}},
+}
+
+// generateCatchAlls generates classRequests requests for each catch-all
+// class, spread over the routes the class applies to.
+func (a *api) generateCatchAlls() {
+	rnd := rand.New(rand.NewSource(*paramSeed))
+	a.catchAlls = make([][]route, len(catchAllClasses))
+	for c, class := range catchAllClasses {
+		var matching []route
+		for _, r := range a.routes {
+			if class.path(r.path, rnd) != "" {
+				matching = append(matching, r)
+			}
+		}
+		spreadRoutes(matching, func(r route) {
+			a.catchAlls[c] = append(a.catchAlls[c], route{r.method, class.path(r.path, rnd)})
+		})
+	}
+}
+
+// TestCatchAll checks that the routers supporting catch-all parameters serve
+// the requests of every catch-all class.
+func TestCatchAll(t *testing.T) {
+	for _, router := range routers {
+		if router.skipTest {
+			continue
+		}
+
+		for _, api := range apis {
+			if !api.supportedBy(router) {
+				continue
+			}
+			var h http.Handler
+
+			for c, requests := range api.catchAlls {
+				for _, request := range requests {
+					if !router.handles(request.method) {
+						continue
+					}
+					if h == nil {
+						h = router.build(api.routes, testHandler)
+					}
+					r, _ := http.NewRequest(request.method, "/", nil)
+					r.URL.Path = request.path
+					r.RequestURI = request.path
+					w := httptest.NewRecorder()
+					h.ServeHTTP(w, r)
+					if w.Code != 200 || w.Body.String() != request.path {
+						t.Errorf("%s in API %s: %s request %s %s: got %d - %s",
+							router.name, api.name, catchAllClasses[c].name,
+							request.method, request.path, w.Code, w.Body.String())
+					}
+				}
+			}
+		}
+	}
+}